@@ -0,0 +1,136 @@
+package rgl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// Page size GetActiveBans uses to walk the ban history
+const BAN_EXPORT_PAGE_SIZE = 100
+
+// Fetch the entire ban history and keep only the bans still in effect (see ActiveBans)
+func (rgl *RGL) GetActiveBans(ctx context.Context) ([]BulkBan, error) {
+	bans, err := rgl.BansPager(BAN_EXPORT_PAGE_SIZE).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ActiveBans(bans, time.Now()), nil
+}
+
+// The bans in effect at now: not yet expired, or permanent.
+// Players with several active bans only appear once, with the one that ends last. Order is otherwise kept.
+func ActiveBans(bans []BulkBan, now time.Time) []BulkBan {
+	index := make(map[SteamID]int)
+	active := make([]BulkBan, 0)
+	for _, ban := range bans {
+		if !ban.Active(now) {
+			continue
+		}
+		i, dup := index[ban.SteamId]
+		if !dup {
+			index[ban.SteamId] = len(active)
+			active = append(active, ban)
+			continue
+		}
+		if prev := active[i]; !prev.Permanent() && (ban.Permanent() || ban.Expires.After(prev.Expires.Time)) {
+			active[i] = ban
+		}
+	}
+	return active
+}
+
+// Whether the ban is in effect at now
+func (b BulkBan) Active(now time.Time) bool {
+	return b.Permanent() || b.Expires.After(now)
+}
+
+// Whether the ban never ends. RGL gives permanent bans a far future expiry like 9999-08-24, so anything past
+// what fits in 32 bit unix seconds (early 2038, the limit for SourceBans and most server plugins) counts too.
+func (b BulkBan) Permanent() bool {
+	return b.Expires.IsZero() || b.Expires.Unix() > math.MaxInt32
+}
+
+// Write bans as banned_user.cfg lines (`banid 0 STEAM_0:1:69252142`), which servers load with `exec banned_user.cfg`.
+// The bans are written as permanent, so regenerate the file regularly from ActiveBans to drop expired ones.
+// Bans with invalid Steam IDs are skipped.
+func WriteBannedUserCfg(w io.Writer, bans []BulkBan) error {
+	for _, ban := range bans {
+		steamID2 := ban.SteamId.SteamID2()
+		if steamID2 == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "banid 0 %s\n", steamID2); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write bans as SourceBans INSERTs into the <tablePrefix>_bans table ("sb" is the SourceBans default).
+// Bans are added under the console (aid 0) on all servers (sid 0), with the reason prefixed by "RGL: ".
+// Bans with invalid Steam IDs are skipped.
+func WriteSourceBansSQL(w io.Writer, bans []BulkBan, tablePrefix string) error {
+	for _, ban := range bans {
+		steamID2 := ban.SteamId.SteamID2()
+		if steamID2 == "" {
+			continue
+		}
+		created := ban.Created.Unix()
+		ends, length := created, int64(0) //SourceBans' permanent ban
+		if !ban.Permanent() {
+			ends = ban.Expires.Unix()
+			length = ends - created
+		}
+		_, err := fmt.Fprintf(w,
+			"INSERT INTO `%s_bans` (`type`, `authid`, `name`, `created`, `ends`, `length`, `reason`, `aid`, `adminIp`, `sid`, `country`) VALUES (0, %s, %s, %d, %d, %d, %s, 0, '', 0, NULL);\n",
+			tablePrefix, sqlQuote(steamID2), sqlQuote(ban.Alias), created, ends, length, sqlQuote("RGL: "+ban.Reason),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A string literal for MySQL
+func sqlQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\x00", `\0`, "\n", `\n`, "\r", `\r`, "\x1a", `\Z`)
+	return "'" + r.Replace(s) + "'"
+}
+
+// A ban as written by WriteBansJSON, with the Steam ID in the formats servers use
+type ExportedBan struct {
+	SteamId  SteamID `json:"steamId"`
+	SteamId2 string  `json:"steamId2"`
+	SteamId3 string  `json:"steamId3"`
+	Alias    string  `json:"alias"`
+	Reason   string  `json:"reason"`
+	Created  Time    `json:"createdAt"`
+	Expires  Time    `json:"expiresAt"` //null for permanent bans (see BulkBan.Permanent)
+}
+
+// Write bans as a json list of ExportedBan
+func WriteBansJSON(w io.Writer, bans []BulkBan) error {
+	exported := make([]ExportedBan, len(bans))
+	for i, ban := range bans {
+		exported[i] = ExportedBan{
+			SteamId:  ban.SteamId,
+			SteamId2: ban.SteamId.SteamID2(),
+			SteamId3: ban.SteamId.SteamID3(),
+			Alias:    ban.Alias,
+			Reason:   ban.Reason,
+			Created:  ban.Created,
+		}
+		if !ban.Permanent() {
+			exported[i].Expires = ban.Expires
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(exported)
+}
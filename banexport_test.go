@@ -0,0 +1,81 @@
+package rgl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+var exportNow = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func exportBans() []BulkBan {
+	day := 24 * time.Hour
+	return []BulkBan{
+		{SteamId: "76561198098770013", Alias: "Zidgel", Reason: "Cheating", Created: Time{exportNow.Add(-day)}, Expires: Time{exportNow.Add(29 * day)}},
+		{SteamId: "76561197970669109", Alias: "b4nny", Reason: "Expired", Created: Time{exportNow.Add(-60 * day)}, Expires: Time{exportNow.Add(-30 * day)}},
+		{SteamId: "76561198011940487", Alias: "o'brien", Reason: "Forever", Created: Time{exportNow.Add(-10 * day)}},
+		{SteamId: "76561198098770013", Alias: "Zidgel", Reason: "Earlier", Created: Time{exportNow.Add(-5 * day)}, Expires: Time{exportNow.Add(day)}},
+		{SteamId: "not an id", Reason: "Bad data", Created: Time{exportNow}},
+		//How RGL actually sends permanent bans
+		{SteamId: "76561198113990147", Alias: "alt", Reason: "Alt account", Created: Time{exportNow.Add(-day)}, Expires: Time{time.Date(9999, 8, 24, 6, 20, 0, 0, time.UTC)}},
+	}
+}
+
+func TestActiveBans(t *testing.T) {
+	active := ActiveBans(exportBans(), exportNow)
+	require.Len(t, active, 4)
+	require.Equal(t, "Cheating", active[0].Reason, "Duplicate players should keep the ban that ends last")
+	require.Equal(t, "Forever", active[1].Reason, "Bans without an expiry should be permanent")
+	require.True(t, active[3].Permanent(), "Bans expiring in 9999 should be permanent")
+}
+
+func TestBanExports(t *testing.T) {
+	active := ActiveBans(exportBans(), exportNow)
+
+	var cfg bytes.Buffer
+	require.NoError(t, WriteBannedUserCfg(&cfg, active))
+	require.Equal(t, "banid 0 STEAM_0:1:69252142\nbanid 0 STEAM_0:1:25837379\nbanid 0 STEAM_0:1:76862209\n", cfg.String(), "Invalid Steam IDs should be skipped")
+
+	var sql bytes.Buffer
+	require.NoError(t, WriteSourceBansSQL(&sql, active, "sb"))
+	lines := strings.Split(strings.TrimSpace(sql.String()), "\n")
+	require.Len(t, lines, 3)
+	created := exportNow.Add(-24 * time.Hour).Unix()
+	require.Equal(t, fmt.Sprintf("INSERT INTO `sb_bans` (`type`, `authid`, `name`, `created`, `ends`, `length`, `reason`, `aid`, `adminIp`, `sid`, `country`) VALUES (0, 'STEAM_0:1:69252142', 'Zidgel', %d, %d, %d, 'RGL: Cheating', 0, '', 0, NULL);", created, created+30*86400, 30*86400), lines[0])
+	require.Contains(t, lines[1], `'o\'brien'`, "Strings should be escaped")
+	require.Contains(t, lines[1], ", 0, 'RGL: Forever'", "Permanent bans should have length 0")
+	require.Contains(t, lines[2], fmt.Sprintf("%d, %d, 0, 'RGL: Alt account'", created, created), "A 9999 expiry shouldn't overflow ends or length")
+
+	var js bytes.Buffer
+	require.NoError(t, WriteBansJSON(&js, active))
+	var exported []ExportedBan
+	require.NoError(t, json.Unmarshal(js.Bytes(), &exported))
+	require.Len(t, exported, 4)
+	require.Equal(t, "[U:1:138504285]", exported[0].SteamId3)
+	require.True(t, exported[1].Expires.IsZero())
+	require.True(t, exported[3].Expires.IsZero(), "Permanent bans should have a null expiry")
+}
+
+func TestGetActiveBans(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/bans/paged", func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("skip") != "0" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `[
+			{"steamId": "76561198098770013", "expiresAt": "2999-01-01T00:00:00.000Z", "createdAt": "2023-01-01T00:00:00.000Z"},
+			{"steamId": "76561197970669109", "expiresAt": "2020-01-01T00:00:00.000Z", "createdAt": "2019-01-01T00:00:00.000Z"}
+		]`)
+	})
+	bans, err := newTestRGL(t, mux).GetActiveBans(context.Background())
+	require.NoError(t, err)
+	require.Len(t, bans, 1)
+	require.Equal(t, SteamID("76561198098770013"), bans[0].SteamId)
+}
@@ -0,0 +1,156 @@
+package rgl
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	BAN_WATCH_INTERVAL  = time.Minute // Default BanWatcher.Interval
+	BAN_WATCH_PAGE_SIZE = 25          // Default BanWatcher.PageSize
+)
+
+// Identifies a ban in the feed. A player can be banned more than once, so SteamId alone isn't enough.
+type BanKey struct {
+	SteamId SteamID `json:"steamId"`
+	Created Time    `json:"createdAt"`
+}
+
+// The key identifying b
+func (b BulkBan) Key() BanKey {
+	return BanKey{SteamId: b.SteamId, Created: b.Created}
+}
+
+// Polls the ban list and reports bans made since the last poll, oldest first.
+// The first poll only records the newest ban (so you don't get the whole history) unless Since is called first.
+type BanWatcher struct {
+	Interval time.Duration   // Time between polls
+	PageSize int             // Bans fetched per request when paging back to the last seen ban
+	OnError  func(err error) // Called when a poll fails in Run or Watch. The next poll tries again from the same place.
+
+	rgl  *RGL
+	mu   sync.Mutex
+	last *BanKey
+}
+
+// Create a BanWatcher with BAN_WATCH_INTERVAL and BAN_WATCH_PAGE_SIZE
+func (rgl *RGL) NewBanWatcher() *BanWatcher {
+	return &BanWatcher{Interval: BAN_WATCH_INTERVAL, PageSize: BAN_WATCH_PAGE_SIZE, rgl: rgl}
+}
+
+// Report bans newer than key on the next poll, e.g. a key saved from Last before a restart
+func (w *BanWatcher) Since(key BanKey) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.last = &key
+}
+
+// The newest ban seen so far, or false before the first poll
+func (w *BanWatcher) Last() (BanKey, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.last == nil {
+		return BanKey{}, false
+	}
+	return *w.last, true
+}
+
+// Fetch bans made since the last poll, oldest first. Only pages back as far as the last seen ban
+// (or the first ban created before it, in case it was removed). Requests bypass the cache.
+func (w *BanWatcher) Poll(ctx context.Context) ([]BulkBan, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ctx = BypassCache(ctx)
+	pager := w.rgl.BansPager(w.PageSize)
+
+	if w.last == nil {
+		if pager.Next(ctx) && len(pager.Page()) > 0 {
+			key := pager.Page()[0].Key()
+			w.last = &key
+		}
+		return nil, pager.Err()
+	}
+
+	fresh := make([]BulkBan, 0)
+	//Bans made while paging shift the list, so a ban from one page can come up again on the next
+	collected := make(map[BanKey]bool)
+	seen := false
+	for !seen && pager.Next(ctx) {
+		for _, ban := range pager.Page() {
+			if ban.Key() == *w.last || ban.Created.Before(w.last.Created.Time) {
+				seen = true
+				break
+			}
+			if collected[ban.Key()] {
+				continue
+			}
+			collected[ban.Key()] = true
+			fresh = append(fresh, ban)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+	if len(fresh) == 0 {
+		return nil, nil
+	}
+	key := fresh[0].Key()
+	w.last = &key
+	//Newest first from RGL, so reverse
+	for i, j := 0, len(fresh)-1; i < j; i, j = i+1, j-1 {
+		fresh[i], fresh[j] = fresh[j], fresh[i]
+	}
+	return fresh, nil
+}
+
+// Poll every Interval (starting now) and call fn for each new ban, until ctx is done.
+// An Interval of 0 or less means BAN_WATCH_INTERVAL. Failed polls go to OnError. Always returns ctx's error.
+func (w *BanWatcher) Run(ctx context.Context, fn func(BulkBan)) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = BAN_WATCH_INTERVAL
+	}
+	return pollEvery(ctx, interval, w.Poll, w.OnError, fn)
+}
+
+// Like Run, but delivers new bans on the returned channel, which is closed once ctx is done
+func (w *BanWatcher) Watch(ctx context.Context) <-chan BulkBan {
+	return watchChan(ctx, w.Run)
+}
+
+// Call poll every interval (starting now, interval must be positive) and fn for each thing it returns, until ctx is done.
+// Errors go to onError (if set). Always returns ctx's error.
+func pollEvery[T any](ctx context.Context, interval time.Duration, poll func(context.Context) ([]T, error), onError func(error), fn func(T)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		found, err := poll(ctx)
+		if err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+		for _, v := range found {
+			fn(v)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Start run in the background, sending what it finds on the returned channel, which is closed once ctx is done
+func watchChan[T any](ctx context.Context, run func(context.Context, func(T)) error) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		run(ctx, func(v T) {
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return ch
+}
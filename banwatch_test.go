@@ -0,0 +1,140 @@
+package rgl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Serves a ban feed that bans can be added to, newest first
+type banFeed struct {
+	mu       sync.Mutex
+	bans     []BulkBan //Oldest first
+	requests int
+	served   func(request int) //Called after each page is written, if set
+}
+
+func (f *banFeed) add(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := 0; i < n; i++ {
+		id := len(f.bans)
+		f.bans = append(f.bans, BulkBan{
+			SteamId: SteamID(fmt.Sprint(76561198000000000 + id)),
+			Created: Time{time.Date(2023, 1, 1, 0, id, 0, 0, time.UTC)},
+		})
+	}
+}
+
+func (f *banFeed) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	f.requests++
+	request, served := f.requests, f.served
+	take, _ := strconv.Atoi(req.URL.Query().Get("take"))
+	skip, _ := strconv.Atoi(req.URL.Query().Get("skip"))
+	page := make([]BulkBan, 0)
+	for i := len(f.bans) - 1 - skip; i >= 0 && len(page) < take; i-- {
+		page = append(page, f.bans[i])
+	}
+	f.mu.Unlock()
+	json.NewEncoder(w).Encode(page)
+	if served != nil {
+		served(request)
+	}
+}
+
+func TestBanWatcherPoll(t *testing.T) {
+	feed := &banFeed{}
+	feed.add(30)
+	tr := newTestRGL(t, feed, WithCache(NewLRUCache(10), nil))
+	ctx := context.Background()
+
+	w := tr.NewBanWatcher()
+	w.PageSize = 5
+	bans, err := w.Poll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, bans, "The first poll shouldn't report history")
+	last, ok := w.Last()
+	require.True(t, ok)
+	require.Equal(t, feed.bans[29].Key(), last)
+
+	bans, err = w.Poll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, bans)
+
+	feed.add(7)
+	feed.requests = 0
+	bans, err = w.Poll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, feed.bans[30:], bans, "New bans should be reported oldest first, past the cache")
+	require.Equal(t, 2, feed.requests, "Should only page back as far as the last seen ban")
+
+	resumed := tr.NewBanWatcher()
+	resumed.Since(feed.bans[34].Key())
+	bans, err = resumed.Poll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, feed.bans[35:], bans)
+}
+
+func TestBanWatcherBanDuringPoll(t *testing.T) {
+	feed := &banFeed{}
+	feed.add(10)
+	tr := newTestRGL(t, feed)
+	ctx := context.Background()
+	w := tr.NewBanWatcher()
+	w.PageSize = 5
+	_, err := w.Poll(ctx)
+	require.NoError(t, err)
+
+	feed.add(7)
+	feed.requests = 0
+	feed.served = func(request int) {
+		if request == 1 {
+			feed.add(1) //Shifts the list, so the second page starts with the last ban of the first
+		}
+	}
+	bans, err := w.Poll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, feed.bans[10:17], bans, "A ban shouldn't be reported twice when the list shifts between pages")
+
+	feed.served = nil
+	bans, err = w.Poll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, feed.bans[17:], bans, "The ban made during the last poll should be reported by the next")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	w.Interval = 0
+	require.NotPanics(t, func() {
+		require.ErrorIs(t, w.Run(cancelled, func(BulkBan) {}), context.Canceled)
+	}, "An Interval of 0 should fall back to the default")
+}
+
+func TestBanWatcherWatch(t *testing.T) {
+	feed := &banFeed{}
+	feed.add(3)
+	tr := newTestRGL(t, feed)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := tr.NewBanWatcher()
+	w.Interval = 10 * time.Millisecond
+	ch := w.Watch(ctx)
+	require.Eventually(t, func() bool {
+		_, ok := w.Last()
+		return ok
+	}, time.Second, time.Millisecond)
+
+	feed.add(2)
+	require.Equal(t, feed.bans[3], <-ch)
+	require.Equal(t, feed.bans[4], <-ch)
+	cancel()
+	for range ch {
+	}
+}
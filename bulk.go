@@ -0,0 +1,93 @@
+package rgl
+
+import (
+	"context"
+	"errors"
+)
+
+// Most IDs sent in a single getmany request. BulkPlayers splits larger inputs into chunks of this size.
+const BULK_PLAYER_LIMIT = 100
+
+// The outcome of BulkPlayersDetailed, with every requested ID accounted for
+type BulkPlayersResult struct {
+	Players  []Player  // Players RGL returned
+	Invalid  []SteamID // IDs that aren't valid Steam IDs (as given), whether caught here, rejected by RGL or unresolvable vanity URLs
+	NotFound []SteamID // Valid IDs that RGL has no profile for (as Steam64s)
+}
+
+// Look up many players at once, in chunks of BULK_PLAYER_LIMIT. Unlike a single getmany request, one bad ID doesn't fail
+// the whole lookup: IDs are normalized to Steam64s and validated first, and if RGL still rejects a chunk it's split up until the bad IDs are found.
+// An error is only returned if a request fails outright, along with everything resolved up to that point.
+func (rgl *RGL) BulkPlayersDetailed(ctx context.Context, ids []SteamID) (BulkPlayersResult, error) {
+	result := BulkPlayersResult{Players: make([]Player, 0)}
+	valid := make([]SteamID, 0, len(ids))
+	given := make(map[SteamID]SteamID, len(ids)) //Steam64 to the ID as the caller gave it (the first, for duplicates)
+	for _, id := range ids {
+		steam64, err := rgl.ResolveSteamID(ctx, string(id))
+		if err != nil {
+			result.Invalid = append(result.Invalid, id)
+			continue
+		}
+		if _, seen := given[steam64]; seen {
+			continue
+		}
+		given[steam64] = id
+		valid = append(valid, steam64)
+	}
+	checked := len(result.Invalid)
+	//IDs RGL rejects are added after checked as Steam64s, report them as given instead
+	asGiven := func() {
+		for i := checked; i < len(result.Invalid); i++ {
+			result.Invalid[i] = given[result.Invalid[i]]
+		}
+	}
+
+	for start := 0; start < len(valid); start += BULK_PLAYER_LIMIT {
+		end := start + BULK_PLAYER_LIMIT
+		if end > len(valid) {
+			end = len(valid)
+		}
+		if err := rgl.bulkChunk(ctx, valid[start:end], &result); err != nil {
+			asGiven()
+			return result, err
+		}
+	}
+
+	found := make(map[SteamID]bool, len(result.Players))
+	for _, p := range result.Players {
+		found[p.SteamId] = true
+	}
+	invalid := make(map[SteamID]bool, len(result.Invalid))
+	for _, id := range result.Invalid {
+		invalid[id] = true
+	}
+	for _, id := range valid {
+		if !found[id] && !invalid[id] {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+	asGiven()
+	return result, nil
+}
+
+// Request one chunk, bisecting it if RGL rejects an ID in it
+func (rgl *RGL) bulkChunk(ctx context.Context, ids []SteamID, result *BulkPlayersResult) error {
+	players, err := rgl.bulkPlayers(ctx, ids)
+	if err == nil {
+		result.Players = append(result.Players, players...)
+		return nil
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code() != "invalid_string" {
+		return err
+	}
+	if len(ids) == 1 {
+		result.Invalid = append(result.Invalid, ids[0])
+		return nil
+	}
+	half := len(ids) / 2
+	if err := rgl.bulkChunk(ctx, ids[:half], result); err != nil {
+		return err
+	}
+	return rgl.bulkChunk(ctx, ids[half:], result)
+}
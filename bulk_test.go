@@ -0,0 +1,55 @@
+package rgl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBulkPlayersDetailed(t *testing.T) {
+	const rejected = "76561199999999999" //Looks valid, but the server rejects it
+	var calls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/profile/getmany", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		var ids []string
+		json.NewDecoder(req.Body).Decode(&ids)
+		require.LessOrEqual(t, len(ids), BULK_PLAYER_LIMIT, "Chunks shouldn't exceed the limit")
+		players := make([]string, 0)
+		for _, id := range ids {
+			if id == rejected {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"statusCode": 400, "error": "Bad Request", "message": [{"code": "invalid_string", "message": "Invalid"}]}`)
+				return
+			}
+			if !strings.HasSuffix(id, "9") { //Pretend only IDs ending in 9 have RGL profiles
+				players = append(players, fmt.Sprintf(`{"steamId": %q}`, id))
+			}
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(players, ","))
+	})
+	tr := newTestRGL(t, mux)
+
+	ids := make([]SteamID, 0)
+	for i := 0; i < 150; i++ {
+		ids = append(ids, SteamID(fmt.Sprintf("7656119800000%04d", i)))
+	}
+	rejectedAsGiven := SteamID(SteamID(rejected).SteamID3())
+	ids = append(ids, "not a steamid", rejectedAsGiven, ids[0])
+
+	result, err := tr.BulkPlayersDetailed(context.Background(), ids)
+	require.NoError(t, err)
+	require.Len(t, result.Players, 135)
+	require.Len(t, result.NotFound, 15)
+	require.ElementsMatch(t, []SteamID{"not a steamid", rejectedAsGiven}, result.Invalid, "Rejected IDs should be reported as given, not as Steam64s")
+	require.Less(t, int(calls), 20, "Should bisect the chunk with the rejected ID rather than retry every ID in it")
+
+	players, err := tr.BulkPlayers(ids)
+	require.NoError(t, err, "One bad ID shouldn't fail the whole lookup")
+	require.Len(t, players, 135)
+}
@@ -0,0 +1,192 @@
+package rgl
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// A store for raw GET responses, keyed by URL. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)                   // The value for key, if present and not expired
+	Set(key string, value []byte, ttl time.Duration) // Store value for key, expiring after ttl
+}
+
+// A Cache that can also hand out expired entries for stale-while-revalidate. When the cache given to WithCache
+// implements this, stale entries are returned immediately and refreshed in the background.
+type StaleCache interface {
+	Cache
+	GetStale(key string) (value []byte, fresh bool, ok bool) // ok is false if key is missing or too stale to use
+}
+
+// The kinds of GET responses that can be cached, so each can have its own TTL
+type Resource int
+
+const (
+	ResourcePlayer      Resource = iota // GetPlayer
+	ResourceTeam                        // GetTeam
+	ResourceSeason                      // GetSeason
+	ResourceMatch                       // GetMatch
+	ResourceTeamHistory                 // GetPlayerTeamHistory
+	ResourceBans                        // GetBans
+)
+
+// How long each kind of resource stays cached. Resources without a positive TTL aren't cached.
+type CacheTTLs map[Resource]time.Duration
+
+// Seasons rarely change once set up so they're kept for a day, bans are kept very briefly, everything else an hour.
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		ResourcePlayer:      time.Hour,
+		ResourceTeam:        time.Hour,
+		ResourceSeason:      24 * time.Hour,
+		ResourceMatch:       time.Hour,
+		ResourceTeamHistory: time.Hour,
+		ResourceBans:        time.Minute,
+	}
+}
+
+// Cache GET responses in c. If ttls is nil, DefaultCacheTTLs() is used. POST searches are never cached.
+func WithCache(c Cache, ttls CacheTTLs) Option {
+	return func(r *RGL) {
+		if ttls == nil {
+			ttls = DefaultCacheTTLs()
+		}
+		r.cache = c
+		r.cacheTTLs = ttls
+		if _, ok := c.(StaleCache); ok {
+			r.revalidating = &keySet{keys: make(map[string]bool)}
+		}
+	}
+}
+
+type bypassCacheKey struct{}
+
+// Requests made with the returned context skip reading from the cache (fresh responses are still stored).
+// Use with the ...Ctx methods: r.GetPlayerCtx(rgl.BypassCache(ctx), id)
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+func bypassCache(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(bool)
+	return bypass
+}
+
+// TTL for a resource, or 0 if it shouldn't be cached
+func (rgl *RGL) cacheTTL(resource Resource) time.Duration {
+	if rgl.cache == nil {
+		return 0
+	}
+	return rgl.cacheTTLs[resource]
+}
+
+// Look up url in the cache. Stale entries from a StaleCache are returned too, and refreshed in the background.
+func (rgl *RGL) cached(url string, ttl time.Duration) ([]byte, bool) {
+	sc, ok := rgl.cache.(StaleCache)
+	if !ok || rgl.revalidating == nil {
+		return rgl.cache.Get(url)
+	}
+	value, fresh, ok := sc.GetStale(url)
+	if ok && !fresh && rgl.revalidating.add(url) {
+		go func() {
+			defer rgl.revalidating.remove(url)
+			raw, err := rgl.fetch(context.Background(), http.MethodGet, url, nil, true)
+			if err == nil { //If this fails the stale value is served until it's too old
+				rgl.cache.Set(url, raw, ttl)
+			}
+		}()
+	}
+	return value, ok
+}
+
+// A set of keys with work in progress
+type keySet struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+// Add key to the set, returning false if it was already there
+func (s *keySet) add(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys[key] {
+		return false
+	}
+	s.keys[key] = true
+	return true
+}
+
+func (s *keySet) remove(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+}
+
+// An in-memory Cache that evicts the least recently used entry when full
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List //Most recently used at the front
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// Create an LRUCache holding at most capacity responses
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires := time.Now().Add(ttl)
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Number of entries currently held (including expired ones not yet evicted)
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
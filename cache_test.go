@@ -0,0 +1,60 @@
+package rgl
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", []byte("1"), time.Hour)
+	c.Set("b", []byte("2"), time.Hour)
+	_, ok := c.Get("a") //a is now most recently used
+	require.True(t, ok)
+	c.Set("c", []byte("3"), time.Hour)
+
+	_, ok = c.Get("b")
+	require.False(t, ok, "Least recently used entry should be evicted")
+	v, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), v)
+	require.Equal(t, 2, c.Len())
+
+	c.Set("d", []byte("4"), -time.Second)
+	_, ok = c.Get("d")
+	require.False(t, ok, "Expired entries shouldn't be returned")
+}
+
+func TestCachedGet(t *testing.T) {
+	var calls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/teams/5979", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `{"teamId": 5979, "name": "nut.city"}`)
+	})
+	mux.HandleFunc("/v0/bans/paged", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `[]`)
+	})
+	tr := newTestRGL(t, mux, WithCache(NewLRUCache(10), CacheTTLs{ResourceTeam: time.Hour}))
+
+	for i := 0; i < 3; i++ {
+		team, err := tr.GetTeam(5979)
+		require.NoError(t, err)
+		require.Equal(t, "nut.city", team.Name)
+	}
+	require.EqualValues(t, 1, calls, "Repeat lookups should be served from the cache")
+
+	_, err := tr.GetTeamCtx(BypassCache(context.Background()), 5979)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, calls, "BypassCache should skip the cache")
+
+	tr.GetBans(10, 0)
+	tr.GetBans(10, 0)
+	require.EqualValues(t, 4, calls, "Resources without a TTL shouldn't be cached")
+}
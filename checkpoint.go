@@ -0,0 +1,82 @@
+package rgl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Save the crawl's progress to path as it goes, and when starting, resume from what's already there.
+// The file is rewritten every CHECKPOINT_EVERY teams/matches/player chunks or CHECKPOINT_INTERVAL (whichever comes first),
+// at the end of each stage and when the crawl fails, so a restarted crawl only fetches what's missing.
+// It's left in place once the crawl finishes; it holds the full snapshot, readable with LoadSeasonSnapshot.
+// ResumeFrom takes precedence over the file's contents, and a file for another season is ignored (then overwritten).
+func WithCheckpoint(path string) CrawlOption {
+	return func(c *crawlConfig) {
+		c.checkpoint = path
+	}
+}
+
+// How often WithCheckpoint rewrites the file. Each write is the whole snapshot, so writing after every item would be quadratic.
+const (
+	CHECKPOINT_EVERY    = 50
+	CHECKPOINT_INTERVAL = 30 * time.Second
+)
+
+// Saves a crawl's snapshot at most every CHECKPOINT_EVERY items or CHECKPOINT_INTERVAL, and at the end of each stage
+type checkpointer struct {
+	path    string
+	pending int       //Progress reports since the last save
+	last    time.Time //Time of the last save
+}
+
+// Note progress p, saving snap if it's time to. Must not run concurrently with changes to snap.
+func (c *checkpointer) progress(snap *SeasonSnapshot, p CrawlProgress) error {
+	c.pending++
+	if p.Done < p.Total && c.pending < CHECKPOINT_EVERY && time.Since(c.last) < CHECKPOINT_INTERVAL {
+		return nil
+	}
+	c.pending = 0
+	c.last = time.Now()
+	return snap.Save(c.path)
+}
+
+// Read a snapshot written by Save or WithCheckpoint. Errors wrap fs.ErrNotExist if there's no file.
+func LoadSeasonSnapshot(path string) (*SeasonSnapshot, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading checkpoint: %w", err)
+	}
+	var snap SeasonSnapshot
+	if err := json.Unmarshal(raw, &snap); err != nil {
+		return nil, fmt.Errorf("Error decoding checkpoint: %w", err)
+	}
+	snap.init()
+	return &snap, nil
+}
+
+// Write the snapshot to path as json. The file is replaced atomically, so a crash mid-write leaves the old one intact.
+func (s *SeasonSnapshot) Save(path string) error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("Error encoding checkpoint: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("Error writing checkpoint: %w", err)
+	}
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("Error writing checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package rgl
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCrawlCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "season67.json")
+	var teamCalls int32
+	failMatches := int32(1)
+	var missingCalls int32 //Requests for things that don't exist
+	inner := crawlHandler(&teamCalls, &failMatches)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/404") || req.URL.Path == "/v0/profile/getmany" {
+			atomic.AddInt32(&missingCalls, 1)
+		}
+		inner.ServeHTTP(w, req)
+	})
+
+	_, err := newTestRGL(t, handler).CrawlSeason(context.Background(), 67, WithCheckpoint(path))
+	require.Error(t, err)
+	saved, err := LoadSeasonSnapshot(path)
+	require.NoError(t, err)
+	require.Len(t, saved.Teams, 2, "Teams fetched before the error should be checkpointed")
+
+	//A new client, like after a restart
+	atomic.StoreInt32(&failMatches, 0)
+	snap, err := newTestRGL(t, handler).CrawlSeason(context.Background(), 67, WithCheckpoint(path))
+	require.NoError(t, err)
+	require.Len(t, snap.Matches, 1)
+	require.Len(t, snap.Players, 3)
+	require.EqualValues(t, 2, atomic.LoadInt32(&teamCalls), "Teams in the checkpoint shouldn't be fetched again")
+	require.Equal(t, "nut.city", snap.Teams[5979].Name)
+
+	saved, err = LoadSeasonSnapshot(path)
+	require.NoError(t, err)
+	require.Equal(t, snap, saved, "The finished snapshot should be left in the checkpoint")
+	require.Equal(t, []int{404}, saved.MissingTeams)
+	require.Equal(t, []int{404}, saved.MissingMatches)
+	require.Equal(t, []SteamID{"76561198000000001"}, saved.MissingPlayers)
+
+	atomic.StoreInt32(&missingCalls, 0)
+	_, err = newTestRGL(t, handler).CrawlSeason(context.Background(), 67, WithCheckpoint(path))
+	require.NoError(t, err)
+	require.Zero(t, atomic.LoadInt32(&missingCalls), "Things known not to exist shouldn't be fetched again")
+
+	_, err = LoadSeasonSnapshot(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestCheckpointThrottle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "season67.json")
+	snap := &SeasonSnapshot{Id: 67}
+	snap.init()
+	c := &checkpointer{path: path, last: time.Now()}
+
+	for i := 1; i < CHECKPOINT_EVERY; i++ {
+		require.NoError(t, c.progress(snap, CrawlProgress{Stage: CrawlMatches, Done: i, Total: 1000}))
+	}
+	require.NoFileExists(t, path, "Shouldn't save after every item")
+	require.NoError(t, c.progress(snap, CrawlProgress{Stage: CrawlMatches, Done: CHECKPOINT_EVERY, Total: 1000}))
+	require.FileExists(t, path, "Should save every CHECKPOINT_EVERY items")
+
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, c.progress(snap, CrawlProgress{Stage: CrawlMatches, Done: 1000, Total: 1000}))
+	require.FileExists(t, path, "Should save at the end of a stage")
+
+	require.NoError(t, os.Remove(path))
+	c.last = time.Now().Add(-CHECKPOINT_INTERVAL)
+	require.NoError(t, c.progress(snap, CrawlProgress{Stage: CrawlPlayers, Done: 1, Total: 1000}))
+	require.FileExists(t, path, "Should save once CHECKPOINT_INTERVAL has passed")
+}
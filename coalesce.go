@@ -0,0 +1,46 @@
+package rgl
+
+import (
+	"context"
+	"sync"
+)
+
+// Deduplicates concurrent calls with the same key, so they share one result (like x/sync/singleflight)
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// A call in progress, or finished and waiting to be read by its callers
+type flight struct {
+	done chan struct{} //Closed once val and err are set
+	val  []byte
+	err  error
+}
+
+// Run fn for key unless a call for key is already running, in which case wait for and return its result.
+// A caller waiting on another's call stops waiting when its own ctx is done, returning ctx's error.
+// shared reports whether the result came from another caller's call. The returned slice must not be modified.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() ([]byte, error)) (val []byte, err error, shared bool) {
+	g.mu.Lock()
+	if f, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.val, f.err, true
+		case <-ctx.Done():
+			return nil, ctx.Err(), true
+		}
+	}
+	f := &flight{done: make(chan struct{})}
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	f.val, f.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(f.done)
+	return f.val, f.err, false
+}
@@ -0,0 +1,81 @@
+package rgl
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescing(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/teams/1234", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		fmt.Fprint(w, `{"teamId": 1234, "name": "froyotech"}`)
+	})
+	tr := newTestRGL(t, mux)
+
+	const callers = 5
+	var wg sync.WaitGroup
+	teams := make([]Team, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			teams[i], errs[i] = tr.GetTeam(1234)
+		}(i)
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond) //Let the rest pile up on the in-flight request
+	close(release)
+	wg.Wait()
+
+	require.EqualValues(t, 1, calls, "Concurrent identical requests should share one round-trip")
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i])
+		require.Equal(t, "froyotech", teams[i].Name)
+	}
+}
+
+func TestCoalescingHonorsEachContext(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/teams/1", func(w http.ResponseWriter, req *http.Request) {
+		started <- struct{}{}
+		<-release
+		fmt.Fprint(w, `{"teamId": 1}`)
+	})
+	tr := newTestRGL(t, mux)
+
+	longCtx, cancelLong := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelLong()
+	var long Team
+	var longErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		long, longErr = tr.GetTeamCtx(longCtx, 1)
+	}()
+	<-started
+
+	shortCtx, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+	start := time.Now()
+	_, err := tr.GetTeamCtx(shortCtx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded, "A caller sharing a request should still give up at its own deadline")
+	require.Less(t, time.Since(start), time.Second)
+
+	close(release)
+	<-done
+	require.NoError(t, longErr, "The caller that made the request shouldn't be affected")
+	require.Equal(t, 1, long.Id)
+}
@@ -0,0 +1,347 @@
+package rgl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Everything in a season, as fetched by CrawlSeason. Teams, matches and players are keyed by ID
+// and linked to each other through the methods below.
+type SeasonSnapshot struct {
+	Id      int                `json:"id"`
+	Season  Season             `json:"season"`
+	Teams   map[int]Team       `json:"teams"`
+	Matches map[int]Match      `json:"matches"`
+	Players map[SteamID]Player `json:"players"`
+
+	// IDs that were fetched but didn't exist (404s, and players RGL didn't return), so resuming doesn't ask again
+	MissingTeams   []int     `json:"missingTeams"`
+	MissingMatches []int     `json:"missingMatches"`
+	MissingPlayers []SteamID `json:"missingPlayers"`
+}
+
+// The stages of a crawl, in order
+type CrawlStage int
+
+const (
+	CrawlTeams CrawlStage = iota
+	CrawlMatches
+	CrawlPlayers
+)
+
+func (s CrawlStage) String() string {
+	switch s {
+	case CrawlTeams:
+		return "teams"
+	case CrawlMatches:
+		return "matches"
+	case CrawlPlayers:
+		return "players"
+	}
+	return "unknown"
+}
+
+// How far through a stage a crawl is. Done counts things already in a resumed snapshot.
+type CrawlProgress struct {
+	Stage CrawlStage
+	Done  int
+	Total int
+}
+
+type crawlConfig struct {
+	progress   func(CrawlProgress)
+	resume     *SeasonSnapshot
+	checkpoint string
+}
+
+// Configures CrawlSeason
+type CrawlOption func(*crawlConfig)
+
+// Call fn as the crawl makes progress. Calls are never concurrent.
+func WithCrawlProgress(fn func(CrawlProgress)) CrawlOption {
+	return func(c *crawlConfig) {
+		c.progress = fn
+	}
+}
+
+// Continue a crawl that failed part way, only fetching what snap doesn't already have. snap is filled in place (ignored if it's for another season).
+func ResumeFrom(snap *SeasonSnapshot) CrawlOption {
+	return func(c *crawlConfig) {
+		c.resume = snap
+	}
+}
+
+// Fetch a season with all its teams, matches and rostered players (RESOLVE_CONCURRENCY requests at a time, under the ratelimiter).
+// If it fails part way, the partial snapshot is returned with the error and can be passed to ResumeFrom to pick up where it stopped.
+// Teams and matches that 404 are left out.
+func (rgl *RGL) CrawlSeason(ctx context.Context, id int, opts ...CrawlOption) (*SeasonSnapshot, error) {
+	cfg := crawlConfig{progress: func(CrawlProgress) {}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	snap := cfg.resume
+	if snap == nil && cfg.checkpoint != "" {
+		loaded, err := LoadSeasonSnapshot(cfg.checkpoint)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		snap = loaded
+	}
+	if snap == nil || snap.Id != id {
+		snap = &SeasonSnapshot{Id: id}
+	}
+	snap.init()
+	var ckpt *checkpointer
+	if cfg.checkpoint != "" {
+		ckpt = &checkpointer{path: cfg.checkpoint, last: time.Now()}
+	}
+	report := func(p CrawlProgress) error {
+		cfg.progress(p)
+		if ckpt == nil {
+			return nil
+		}
+		return ckpt.progress(snap, p)
+	}
+	//Keep whatever was fetched since the last checkpoint. Nothing else is running by the time this is called.
+	fail := func(err error) (*SeasonSnapshot, error) {
+		if ckpt != nil {
+			snap.Save(ckpt.path) //err is the one worth returning
+		}
+		return snap, err
+	}
+
+	//Always refetched, since a running season gains matches
+	season, err := rgl.GetSeasonCtx(ctx, id)
+	if err != nil {
+		return snap, err
+	}
+	snap.Season = season
+
+	var mu sync.Mutex //Guards snap and serializes progress calls
+	err = crawlIds(ctx, CrawlTeams, season.Teams, snap.Teams, &snap.MissingTeams, &mu, report, func(ctx context.Context, id int) (bool, error) {
+		team, err := rgl.GetTeamCtx(ctx, id)
+		if errors.Is(err, ErrNotFound) || (err == nil && team.Id == 0) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		mu.Lock()
+		snap.Teams[id] = team
+		mu.Unlock()
+		return true, nil
+	})
+	if err != nil {
+		return fail(fmt.Errorf("Error crawling teams: %w", err))
+	}
+
+	err = crawlIds(ctx, CrawlMatches, season.Matches, snap.Matches, &snap.MissingMatches, &mu, report, func(ctx context.Context, id int) (bool, error) {
+		match, err := rgl.GetMatchCtx(ctx, id)
+		if errors.Is(err, ErrNotFound) || (err == nil && match.Id == 0) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		mu.Lock()
+		snap.Matches[id] = match
+		mu.Unlock()
+		return true, nil
+	})
+	if err != nil {
+		return fail(fmt.Errorf("Error crawling matches: %w", err))
+	}
+
+	if err := rgl.crawlPlayers(ctx, snap, report); err != nil {
+		return fail(fmt.Errorf("Error crawling players: %w", err))
+	}
+	return snap, nil
+}
+
+// Call fetch for each id not already in have or missing, reporting progress after each.
+// fetch returns false for ids that don't exist, which are added to missing.
+func crawlIds[T any](ctx context.Context, stage CrawlStage, ids []int, have map[int]T, missing *[]int, mu *sync.Mutex, report func(CrawlProgress) error, fetch func(ctx context.Context, id int) (bool, error)) error {
+	absent := make(map[int]bool, len(*missing))
+	for _, id := range *missing {
+		absent[id] = true
+	}
+	todo := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := have[id]; !ok && !absent[id] {
+			todo = append(todo, id)
+		}
+	}
+	done := len(ids) - len(todo)
+	if err := report(CrawlProgress{Stage: stage, Done: done, Total: len(ids)}); err != nil {
+		return err
+	}
+	return forEachLimit(ctx, len(todo), RESOLVE_CONCURRENCY, func(ctx context.Context, i int) error {
+		found, err := fetch(ctx, todo[i])
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if !found {
+			*missing = append(*missing, todo[i])
+		}
+		done++
+		return report(CrawlProgress{Stage: stage, Done: done, Total: len(ids)})
+	})
+}
+
+// BulkPlayers every rostered player not already in snap (or known to be missing), one BULK_PLAYER_LIMIT chunk at a time
+func (rgl *RGL) crawlPlayers(ctx context.Context, snap *SeasonSnapshot, report func(CrawlProgress) error) error {
+	all := snap.rosteredPlayers()
+	absent := make(map[SteamID]bool, len(snap.MissingPlayers))
+	for _, id := range snap.MissingPlayers {
+		absent[id] = true
+	}
+	todo := make([]SteamID, 0, len(all))
+	for _, id := range all {
+		if _, ok := snap.Players[id]; !ok && !absent[id] {
+			todo = append(todo, id)
+		}
+	}
+	done := len(all) - len(todo)
+	if err := report(CrawlProgress{Stage: CrawlPlayers, Done: done, Total: len(all)}); err != nil {
+		return err
+	}
+	for start := 0; start < len(todo); start += BULK_PLAYER_LIMIT {
+		end := start + BULK_PLAYER_LIMIT
+		if end > len(todo) {
+			end = len(todo)
+		}
+		chunk := todo[start:end]
+		result, err := rgl.BulkPlayersDetailed(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		for _, p := range result.Players {
+			snap.Players[p.SteamId] = p
+		}
+		for _, id := range chunk { //Invalid or not found
+			if _, ok := snap.Players[id]; !ok {
+				snap.MissingPlayers = append(snap.MissingPlayers, id)
+			}
+		}
+		done += len(chunk)
+		if err := report(CrawlProgress{Stage: CrawlPlayers, Done: done, Total: len(all)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Every player on any team's roster, without duplicates, sorted
+func (s *SeasonSnapshot) rosteredPlayers() []SteamID {
+	seen := make(map[SteamID]bool)
+	ids := make([]SteamID, 0)
+	for _, t := range s.Teams {
+		for _, p := range t.Players {
+			if !seen[p.SteamId] {
+				seen[p.SteamId] = true
+				ids = append(ids, p.SteamId)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Make any nil maps, e.g. from decoding json that didn't have them
+func (s *SeasonSnapshot) init() {
+	if s.Teams == nil {
+		s.Teams = make(map[int]Team)
+	}
+	if s.Matches == nil {
+		s.Matches = make(map[int]Match)
+	}
+	if s.Players == nil {
+		s.Players = make(map[SteamID]Player)
+	}
+}
+
+// The season's matches involving a team, by match date
+func (s *SeasonSnapshot) TeamMatches(teamId int) []Match {
+	matches := make([]Match, 0)
+	for _, m := range s.Matches {
+		if _, ok := m.Team(teamId); ok {
+			matches = append(matches, m)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].MatchDate.Equal(matches[j].MatchDate.Time) {
+			return matches[i].MatchDate.Before(matches[j].MatchDate.Time)
+		}
+		return matches[i].Id < matches[j].Id
+	})
+	return matches
+}
+
+// The full Players on a team's roster, in roster order. Players that weren't fetched are left out.
+func (s *SeasonSnapshot) Roster(teamId int) []Player {
+	players := make([]Player, 0)
+	for _, tp := range s.Teams[teamId].Players {
+		if p, ok := s.Players[tp.SteamId]; ok {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// The season's teams a player is rostered on (usually one), by team ID
+func (s *SeasonSnapshot) PlayerTeams(id SteamID) []Team {
+	teams := make([]Team, 0)
+	for _, t := range s.Teams {
+		for _, tp := range t.Players {
+			if tp.SteamId == id {
+				teams = append(teams, t)
+				break
+			}
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Id < teams[j].Id })
+	return teams
+}
+
+// The full Teams playing a match, home first. false if the match or either team isn't in the snapshot,
+// or the sides can't be told (see Match.Home).
+func (s *SeasonSnapshot) MatchTeams(matchId int) (home Team, away Team, ok bool) {
+	m, ok := s.Matches[matchId]
+	if !ok {
+		return Team{}, Team{}, false
+	}
+	homeTeam, ok := m.Home()
+	if !ok {
+		return Team{}, Team{}, false
+	}
+	awayTeam, _ := m.Away()
+	home, homeOk := s.Teams[homeTeam.Id]
+	away, awayOk := s.Teams[awayTeam.Id]
+	if !homeOk || !awayOk {
+		return Team{}, Team{}, false
+	}
+	return home, away, true
+}
+
+// Standings for the snapshot's teams and matches (see ComputeStandings)
+func (s *SeasonSnapshot) Standings() []DivisionStandings {
+	teams := make([]Team, 0, len(s.Teams))
+	for _, id := range s.Season.Teams {
+		if t, ok := s.Teams[id]; ok {
+			teams = append(teams, t)
+		}
+	}
+	matches := make([]Match, 0, len(s.Matches))
+	for _, m := range s.Matches {
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Id < matches[j].Id })
+	return ComputeStandings(teams, matches)
+}
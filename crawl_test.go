@@ -0,0 +1,95 @@
+package rgl
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// A season with two teams of two players (one RGL won't return) and one match, plus a team and match that 404. Requests to /v0/matches/ fail while failMatches is set.
+func crawlHandler(teamCalls *int32, failMatches *int32) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/seasons/67", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"name": "Sixes S2", "participatingTeams": [5979, 5819, 404], "matchesPlayedDuringSeason": [5256, 404]}`)
+	})
+	mux.HandleFunc("/v0/teams/5979", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(teamCalls, 1)
+		fmt.Fprint(w, `{"teamId": 5979, "name": "nut.city", "divisionId": 12, "players": [
+			{"steamId": "76561198098770013", "name": "Zidgel"}, {"steamId": "76561197970669109", "name": "b4nny"}
+		]}`)
+	})
+	mux.HandleFunc("/v0/teams/5819", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(teamCalls, 1)
+		fmt.Fprint(w, `{"teamId": 5819, "name": "Sunny", "divisionId": 12, "players": [
+			{"steamId": "76561198011940487", "name": "sunny"}, {"steamId": "76561198000000001", "name": "deleted"}
+		]}`)
+	})
+	mux.HandleFunc("/v0/matches/5256", func(w http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(failMatches) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"matchId": 5256, "winner": 5979, "teams": [
+			{"teamId": 5979, "isHome": true, "points": "3"}, {"teamId": 5819, "points": "0"}
+		], "maps": [{"mapName": "cp_snakewater_final1", "homeScore": 5, "awayScore": 1}]}`)
+	})
+	mux.HandleFunc("/v0/profile/getmany", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `[{"steamId": "76561198098770013", "name": "Captain Zidgel"}, {"steamId": "76561197970669109", "name": "b4nny"}, {"steamId": "76561198011940487", "name": "sunny"}]`)
+	})
+	return mux
+}
+
+func TestCrawlSeason(t *testing.T) {
+	var teamCalls, failMatches int32
+	tr := newTestRGL(t, crawlHandler(&teamCalls, &failMatches))
+
+	last := make(map[CrawlStage]CrawlProgress)
+	snap, err := tr.CrawlSeason(context.Background(), 67, WithCrawlProgress(func(p CrawlProgress) {
+		last[p.Stage] = p
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "Sixes S2", snap.Season.Name)
+	require.Len(t, snap.Teams, 2)
+	require.Len(t, snap.Matches, 1)
+	require.Len(t, snap.Players, 3)
+	require.Equal(t, CrawlProgress{Stage: CrawlPlayers, Done: 4, Total: 4}, last[CrawlPlayers])
+	require.Equal(t, CrawlProgress{Stage: CrawlTeams, Done: 3, Total: 3}, last[CrawlTeams])
+
+	roster := snap.Roster(5979)
+	require.Len(t, roster, 2)
+	require.Equal(t, "Captain Zidgel", roster[0].Name)
+	require.Equal(t, 5819, snap.PlayerTeams("76561198011940487")[0].Id)
+	require.Len(t, snap.TeamMatches(5819), 1)
+	home, away, ok := snap.MatchTeams(5256)
+	require.True(t, ok)
+	require.Equal(t, "nut.city", home.Name)
+	require.Equal(t, "Sunny", away.Name)
+	require.Equal(t, 3.0, snap.Standings()[0].Rows[0].Points)
+
+	snap, err = newTestRGL(t, crawlHandler(&teamCalls, &failMatches), WithNotFoundError()).CrawlSeason(context.Background(), 67)
+	require.NoError(t, err, "Teams and matches that 404 should be left out with WithNotFoundError too")
+	require.Len(t, snap.Teams, 2)
+	require.Len(t, snap.Matches, 1)
+}
+
+func TestCrawlSeasonResume(t *testing.T) {
+	var teamCalls int32
+	failMatches := int32(1)
+	tr := newTestRGL(t, crawlHandler(&teamCalls, &failMatches))
+
+	snap, err := tr.CrawlSeason(context.Background(), 67)
+	require.Error(t, err)
+	require.Len(t, snap.Teams, 2, "Teams fetched before the error should be kept")
+	require.Empty(t, snap.Matches)
+
+	atomic.StoreInt32(&failMatches, 0)
+	resumed, err := tr.CrawlSeason(context.Background(), 67, ResumeFrom(snap))
+	require.NoError(t, err)
+	require.Same(t, snap, resumed, "Resuming should fill in the snapshot given")
+	require.Len(t, snap.Matches, 1)
+	require.Len(t, snap.Players, 3)
+	require.EqualValues(t, 2, atomic.LoadInt32(&teamCalls), "Teams shouldn't be fetched again")
+}
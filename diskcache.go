@@ -0,0 +1,136 @@
+package rgl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// A StaleCache that stores responses as json files in a directory, so it survives restarts.
+// Entries past their TTL are still served (and refreshed in the background) for up to StaleFor.
+type DiskCache struct {
+	Dir      string
+	StaleFor time.Duration
+}
+
+// What's written to each file. Body is the response json exactly as RGL sent it.
+type diskEntry struct {
+	Key     string          `json:"key"`
+	Expires time.Time       `json:"expires"`
+	Body    json.RawMessage `json:"body"`
+}
+
+// Create a DiskCache in dir (created if missing). Expired entries are served for up to staleFor while they're refreshed.
+func NewDiskCache(dir string, staleFor time.Duration) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("Error creating cache dir: %w", err)
+	}
+	return &DiskCache{Dir: dir, StaleFor: staleFor}, nil
+}
+
+// TTLs suited to a DiskCache: only players, teams, seasons and matches are stored.
+// Seasons and matches are kept long since finished ones never change.
+func DiskCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		ResourcePlayer: time.Hour,
+		ResourceTeam:   time.Hour,
+		ResourceSeason: 7 * 24 * time.Hour,
+		ResourceMatch:  24 * time.Hour,
+	}
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	value, fresh, ok := c.GetStale(key)
+	return value, ok && fresh
+}
+
+func (c *DiskCache) GetStale(key string) ([]byte, bool, bool) {
+	raw, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false, false
+	}
+	var entry diskEntry
+	if json.Unmarshal(raw, &entry) != nil || entry.Key != key {
+		return nil, false, false
+	}
+	now := time.Now()
+	if c.dead(entry, now) {
+		os.Remove(c.path(key)) //Never served again, so don't let it take up space
+		return nil, false, false
+	}
+	return entry.Body, !now.After(entry.Expires), true
+}
+
+// Whether entry is too stale to ever be served
+func (c *DiskCache) dead(entry diskEntry, now time.Time) bool {
+	return now.After(entry.Expires.Add(c.StaleFor))
+}
+
+// Delete every entry too stale to be served, unreadable files, and temp files left by interrupted writes.
+// Dead entries are also removed when they're read, but ones that never are would otherwise stay forever.
+// Returns how many files were removed.
+func (c *DiskCache) Prune() (int, error) {
+	files, err := os.ReadDir(c.Dir)
+	if err != nil {
+		return 0, fmt.Errorf("Error reading cache dir: %w", err)
+	}
+	now := time.Now()
+	removed := 0
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		path := filepath.Join(c.Dir, f.Name())
+		switch {
+		case strings.HasPrefix(f.Name(), "tmp-"):
+			info, err := f.Info()
+			if err != nil || now.Sub(info.ModTime()) < time.Hour { //Might still be being written
+				continue
+			}
+		case strings.HasSuffix(f.Name(), ".json"):
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			var entry diskEntry
+			if json.Unmarshal(raw, &entry) == nil && !c.dead(entry, now) {
+				continue
+			}
+		default:
+			continue
+		}
+		if os.Remove(path) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	raw, err := json.Marshal(diskEntry{Key: key, Expires: time.Now().Add(ttl), Body: value})
+	if err != nil { //Not json, don't bother
+		return
+	}
+	//Write then rename so readers never see a partial file
+	tmp, err := os.CreateTemp(c.Dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || os.Rename(tmp.Name(), c.path(key)) != nil {
+		os.Remove(tmp.Name())
+	}
+}
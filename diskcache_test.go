@@ -0,0 +1,90 @@
+package rgl
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, time.Hour)
+	require.NoError(t, err)
+
+	c.Set("https://api.rgl.gg/v0/seasons/107", []byte(`{"name": "P7 Season 9"}`), time.Hour)
+	v, ok := c.Get("https://api.rgl.gg/v0/seasons/107")
+	require.True(t, ok)
+	require.JSONEq(t, `{"name": "P7 Season 9"}`, string(v))
+
+	reopened, err := NewDiskCache(dir, time.Hour)
+	require.NoError(t, err)
+	_, ok = reopened.Get("https://api.rgl.gg/v0/seasons/107")
+	require.True(t, ok, "Entries should survive a new instance")
+
+	c.Set("stale", []byte(`{}`), -time.Minute)
+	_, ok = c.Get("stale")
+	require.False(t, ok, "Get shouldn't return stale entries")
+	_, fresh, ok := c.GetStale("stale")
+	require.True(t, ok, "GetStale should return entries within StaleFor")
+	require.False(t, fresh)
+
+	c.Set("dead", []byte(`{}`), -2*time.Hour)
+	_, _, ok = c.GetStale("dead")
+	require.False(t, ok, "Entries past StaleFor shouldn't be returned at all")
+	_, err = os.Stat(c.path("dead"))
+	require.True(t, os.IsNotExist(err), "Dead entries should be removed when read")
+}
+
+func TestDiskCachePrune(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, time.Hour)
+	require.NoError(t, err)
+
+	c.Set("fresh", []byte(`{}`), time.Hour)
+	c.Set("stale", []byte(`{}`), -time.Minute)
+	c.Set("dead", []byte(`{}`), -2*time.Hour)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt.json"), []byte("{"), 0o644))
+	oldTmp := filepath.Join(dir, "tmp-old")
+	require.NoError(t, os.WriteFile(oldTmp, []byte("{"), 0o644))
+	require.NoError(t, os.Chtimes(oldTmp, time.Now().Add(-2*time.Hour), time.Now().Add(-2*time.Hour)))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tmp-new"), []byte("{"), 0o644))
+
+	removed, err := c.Prune()
+	require.NoError(t, err)
+	require.Equal(t, 3, removed, "Should remove the dead entry, the corrupt file and the old temp file")
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	require.ElementsMatch(t, []string{filepath.Base(c.path("fresh")), filepath.Base(c.path("stale")), "tmp-new"}, names)
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	var calls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/teams/5979", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, `{"teamId": 5979, "name": "name %d"}`, atomic.AddInt32(&calls, 1))
+	})
+	c, err := NewDiskCache(t.TempDir(), time.Hour)
+	require.NoError(t, err)
+	tr := newTestRGL(t, mux, WithCache(c, DiskCacheTTLs()))
+
+	c.Set(tr.endpoint(teamPath+"5979"), []byte(`{"teamId": 5979, "name": "old name"}`), -time.Minute)
+	team, err := tr.GetTeam(5979)
+	require.NoError(t, err)
+	require.Equal(t, "old name", team.Name, "Stale entry should be served immediately")
+
+	require.Eventually(t, func() bool {
+		team, err = tr.GetTeam(5979)
+		return err == nil && team.Name == "name 1"
+	}, time.Second, 10*time.Millisecond, "Stale entry should be refreshed in the background")
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
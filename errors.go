@@ -0,0 +1,82 @@
+package rgl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Returned (wrapped) when RGL responds 404. Methods that treat 404 as "no results" swallow this unless configured otherwise.
+var ErrNotFound = errors.New("Not Found")
+
+// Returned (wrapped) when RGL responds 429.
+var ErrRateLimited = errors.New("Hit ratelimit")
+
+// Any non-2xx response from RGL. Use errors.As to inspect it, or errors.Is with ErrNotFound/ErrRateLimited.
+type APIError struct {
+	StatusCode     int                // HTTP status code of the response
+	Endpoint       string             // URL that was requested
+	ReportedStatus int                // statusCode field of the PostError body, if there was one. RGL sometimes reports a different code than the response has.
+	Messages       []PostErrorMessage // Decoded PostError messages, if the body was a PostError
+	Body           string             // Raw body, if it wasn't a PostError (ie an error page during an outage)
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("RGL responded %d for %s", e.StatusCode, e.Endpoint)
+	if len(e.Messages) > 0 {
+		parts := make([]string, len(e.Messages))
+		for i, m := range e.Messages {
+			parts[i] = fmt.Sprintf("%s (%s)", m.Message, m.Code)
+		}
+		msg += ": " + strings.Join(parts, "; ")
+	}
+	return msg
+}
+
+// Lets errors.Is match ErrNotFound and ErrRateLimited
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.ReportedStatus == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// The code of the first PostError message, or "" if there were none
+func (e *APIError) Code() string {
+	if len(e.Messages) == 0 {
+		return ""
+	}
+	return e.Messages[0].Code
+}
+
+// Build an APIError from a non-2xx response, consuming and closing its body
+func newAPIError(resp *http.Response) *APIError {
+	defer resp.Body.Close()
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if resp.Request != nil {
+		apiErr.Endpoint = resp.Request.URL.String()
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	//PostError.Message is usually a list, but plain NestJS errors (ie 404s) send a single string
+	var pe struct {
+		StatusCode int             `json:"statusCode"`
+		Error      string          `json:"error"`
+		Message    json.RawMessage `json:"message"`
+	}
+	if json.Unmarshal(raw, &pe) != nil || pe.StatusCode == 0 {
+		apiErr.Body = string(raw)
+		return apiErr
+	}
+	apiErr.ReportedStatus = pe.StatusCode
+	var single string
+	if json.Unmarshal(pe.Message, &apiErr.Messages) != nil && json.Unmarshal(pe.Message, &single) == nil {
+		apiErr.Messages = []PostErrorMessage{{Code: pe.Error, Message: single}}
+	}
+	return apiErr
+}
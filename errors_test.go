@@ -0,0 +1,49 @@
+package rgl
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/teams/1", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("/v0/teams/2", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "<html>Bad Gateway</html>")
+	})
+	mux.HandleFunc("/v0/teams/3", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"statusCode": 404, "message": "Team not found", "error": "Not Found"}`)
+	})
+	mux.HandleFunc("/v0/search/teams", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"statusCode": 400, "error": "Bad Request", "message": [{"code": "too_small", "message": "Too short"}]}`)
+	})
+	tr := newTestRGL(t, mux)
+
+	_, err := tr.GetTeam(1)
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	_, err = tr.GetTeam(2)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr, "Server errors should be APIErrors, not json decoding errors")
+	require.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	require.Equal(t, "<html>Bad Gateway</html>", apiErr.Body)
+	require.False(t, errors.Is(err, ErrNotFound))
+
+	team, err := tr.GetTeam(3)
+	require.NoError(t, err, "404 should still be swallowed")
+	require.Equal(t, Team{}, team)
+
+	_, err = tr.SearchTeams("froyo", 1, 0)
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, "too_small", apiErr.Code())
+	require.Contains(t, apiErr.Endpoint, "/v0/search/teams")
+	require.Equal(t, http.StatusBadRequest, apiErr.ReportedStatus)
+}
@@ -0,0 +1,220 @@
+package rgl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A game format RGL runs seasons in
+type Format int
+
+const (
+	FormatUnknown    Format = iota
+	FormatSixes             // 6v6
+	FormatHighlander        // 9v9, one of each class
+	FormatProlander         // 7v7
+	FormatTradSixes         // Traditional Sixes, a Sixes variant
+	FormatNRSixes           // No Restrictions Sixes, a Sixes variant
+)
+
+// Canonical names, as RGL writes them
+var formatNames = map[Format]string{
+	FormatSixes:      "Sixes",
+	FormatHighlander: "Highlander",
+	FormatProlander:  "Prolander",
+	FormatTradSixes:  "Trad. Sixes",
+	FormatNRSixes:    "NR Sixes",
+}
+
+// Everything ParseFormat accepts (lowercased), including common shorthand
+var formatAliases = map[string]Format{
+	"sixes":                 FormatSixes,
+	"6s":                    FormatSixes,
+	"6v6":                   FormatSixes,
+	"highlander":            FormatHighlander,
+	"hl":                    FormatHighlander,
+	"9v9":                   FormatHighlander,
+	"prolander":             FormatProlander,
+	"pl":                    FormatProlander,
+	"7v7":                   FormatProlander,
+	"trad. sixes":           FormatTradSixes,
+	"trad sixes":            FormatTradSixes,
+	"traditional sixes":     FormatTradSixes,
+	"nr sixes":              FormatNRSixes,
+	"nr6s":                  FormatNRSixes,
+	"no restrictions sixes": FormatNRSixes,
+}
+
+// Parse a format name like "Sixes", "Trad. Sixes" or "HL" (case insensitive)
+func ParseFormat(name string) (Format, error) {
+	if f, ok := formatAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return f, nil
+	}
+	return FormatUnknown, fmt.Errorf("Unknown format %q", name)
+}
+
+// RGL formatIds (PlayerTeamHistory.FormatId). Only IDs confirmed from API responses are listed, others are FormatUnknown.
+var formatIds = map[int]Format{
+	3: FormatSixes, //GET /v0/profile/76561198098770013/teams
+}
+
+// The format for an RGL formatId, or an error if it isn't a known one
+func ParseFormatId(id int) (Format, error) {
+	if f, ok := formatIds[id]; ok {
+		return f, nil
+	}
+	return FormatUnknown, fmt.Errorf("Unknown format id %d", id)
+}
+
+func (f Format) String() string {
+	if name, ok := formatNames[f]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// Players per team on the field at once, or 0 for FormatUnknown
+func (f Format) PlayerCount() int {
+	switch f {
+	case FormatSixes, FormatTradSixes, FormatNRSixes:
+		return 6
+	case FormatHighlander:
+		return 9
+	case FormatProlander:
+		return 7
+	}
+	return 0
+}
+
+// The main format f is a variant of (FormatSixes for the Sixes variants), or f itself
+func (f Format) Base() Format {
+	switch f {
+	case FormatTradSixes, FormatNRSixes:
+		return FormatSixes
+	}
+	return f
+}
+
+// A region RGL runs seasons in
+type Region string
+
+const (
+	RegionUnknown      Region = ""
+	RegionNorthAmerica Region = "NA"
+	RegionEurope       Region = "EU"
+	RegionSouthAmerica Region = "SA"
+	RegionOceania      Region = "OCE"
+	RegionAsia         Region = "Asia"
+)
+
+var regionAliases = map[string]Region{
+	"na":            RegionNorthAmerica,
+	"north america": RegionNorthAmerica,
+	"eu":            RegionEurope,
+	"europe":        RegionEurope,
+	"sa":            RegionSouthAmerica,
+	"south america": RegionSouthAmerica,
+	"oce":           RegionOceania,
+	"au":            RegionOceania,
+	"oceania":       RegionOceania,
+	"asia":          RegionAsia,
+}
+
+// Parse a region like "NA" or "Europe" (case insensitive)
+func ParseRegion(name string) (Region, error) {
+	if r, ok := regionAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return r, nil
+	}
+	return RegionUnknown, fmt.Errorf("Unknown region %q", name)
+}
+
+// RGL regionIds (PlayerTeamHistory.RegionId) are a region and format together, like RGL's region names.
+// Only IDs confirmed from API responses are listed.
+var regionIds = map[int]struct {
+	region Region
+	format Format
+}{
+	40: {RegionNorthAmerica, FormatSixes}, //"NA Sixes", GET /v0/profile/76561198098770013/teams
+}
+
+// The region and format for an RGL regionId, or an error if it isn't a known one
+func ParseRegionId(id int) (Region, Format, error) {
+	if r, ok := regionIds[id]; ok {
+		return r.region, r.format, nil
+	}
+	return RegionUnknown, FormatUnknown, fmt.Errorf("Unknown region id %d", id)
+}
+
+// RGL's region names include the format, like "NA Sixes" or "NA Trad. Sixes". Split one into both parts.
+func ParseRegionName(name string) (Region, Format, error) {
+	prefix, rest, _ := strings.Cut(strings.TrimSpace(name), " ")
+	region, err := ParseRegion(prefix)
+	if err != nil {
+		return RegionUnknown, FormatUnknown, err
+	}
+	if rest == "" {
+		return region, FormatUnknown, nil
+	}
+	format, err := ParseFormat(rest)
+	return region, format, err
+}
+
+// The team in format f (Sixes variants use the Sixes team), or nil if there isn't one
+func (c CurrentTeams) ByFormat(f Format) *CurrTeam {
+	switch f.Base() {
+	case FormatSixes:
+		return c.Sixes
+	case FormatHighlander:
+		return c.Highlander
+	case FormatProlander:
+		return c.Prolander
+	}
+	return nil
+}
+
+// Every current team, by format. Formats without a team aren't included.
+func (c CurrentTeams) All() map[Format]*CurrTeam {
+	all := make(map[Format]*CurrTeam)
+	for _, f := range []Format{FormatSixes, FormatHighlander, FormatProlander} {
+		if team := c.ByFormat(f); team != nil {
+			all[f] = team
+		}
+	}
+	return all
+}
+
+// The format of this team's season, from FormatName, or FormatId if the name isn't recognized
+func (h PlayerTeamHistory) Format() Format {
+	if f, err := ParseFormat(h.FormatName); err == nil {
+		return f
+	}
+	f, _ := ParseFormatId(h.FormatId)
+	return f
+}
+
+// The region of this team's season, from RegionName, or RegionId if the name isn't recognized
+func (h PlayerTeamHistory) Region() Region {
+	if r, _, err := ParseRegionName(h.RegionName); err == nil {
+		return r
+	}
+	r, _, _ := ParseRegionId(h.RegionId)
+	return r
+}
+
+// The season's format, or FormatUnknown if RGL didn't give one (Season.Format is often null)
+func (s Season) FormatType() Format {
+	if s.Format == nil {
+		return FormatUnknown
+	}
+	f, _ := ParseFormat(*s.Format)
+	return f
+}
+
+// The season's region, or RegionUnknown if RGL didn't give one
+func (s Season) RegionType() Region {
+	if s.Region == nil {
+		return RegionUnknown
+	}
+	r, _, _ := ParseRegionName(*s.Region)
+	return r
+}
@@ -0,0 +1,71 @@
+package rgl
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	for name, want := range map[string]Format{
+		"Sixes":       FormatSixes,
+		"6s":          FormatSixes,
+		"HL":          FormatHighlander,
+		" prolander ": FormatProlander,
+		"Trad. Sixes": FormatTradSixes,
+		"NR Sixes":    FormatNRSixes,
+	} {
+		f, err := ParseFormat(name)
+		require.NoError(t, err, name)
+		require.Equal(t, want, f, name)
+	}
+	_, err := ParseFormat("ultiduo")
+	require.Error(t, err)
+
+	require.Equal(t, "Trad. Sixes", FormatTradSixes.String())
+	require.Equal(t, 9, FormatHighlander.PlayerCount())
+	require.Equal(t, 6, FormatNRSixes.PlayerCount())
+	require.Equal(t, FormatSixes, FormatTradSixes.Base())
+}
+
+func TestRegion(t *testing.T) {
+	region, format, err := ParseRegionName("NA Trad. Sixes")
+	require.NoError(t, err)
+	require.Equal(t, RegionNorthAmerica, region)
+	require.Equal(t, FormatTradSixes, format)
+
+	_, _, err = ParseRegionName("Narnia Sixes")
+	require.Error(t, err)
+
+	h := PlayerTeamHistory{FormatId: 3, FormatName: "Sixes", RegionName: "NA Sixes"}
+	require.Equal(t, FormatSixes, h.Format())
+	require.Equal(t, RegionNorthAmerica, h.Region())
+	require.Equal(t, FormatSixes, PlayerTeamHistory{FormatId: 3}.Format(), "Should fall back to FormatId")
+	require.Equal(t, RegionNorthAmerica, PlayerTeamHistory{RegionId: 40}.Region(), "Should fall back to RegionId")
+
+	f, err := ParseFormatId(3)
+	require.NoError(t, err)
+	require.Equal(t, FormatSixes, f)
+	region, format, err = ParseRegionId(40)
+	require.NoError(t, err)
+	require.Equal(t, RegionNorthAmerica, region)
+	require.Equal(t, FormatSixes, format)
+	_, err = ParseFormatId(-1)
+	require.Error(t, err)
+	_, _, err = ParseRegionId(-1)
+	require.Error(t, err)
+
+	prolander, na := "Prolander", "NA Prolander"
+	s := Season{Format: &prolander, Region: &na}
+	require.Equal(t, FormatProlander, s.FormatType())
+	require.Equal(t, RegionNorthAmerica, s.RegionType())
+	require.Equal(t, FormatUnknown, Season{}.FormatType(), "Null formats should be FormatUnknown")
+}
+
+func TestCurrentTeamsByFormat(t *testing.T) {
+	sixes := &CurrTeam{Id: 5979}
+	c := CurrentTeams{Sixes: sixes}
+	require.Equal(t, sixes, c.ByFormat(FormatSixes))
+	require.Equal(t, sixes, c.ByFormat(FormatTradSixes), "Sixes variants should use the Sixes team")
+	require.Nil(t, c.ByFormat(FormatHighlander))
+	require.Equal(t, map[Format]*CurrTeam{FormatSixes: sixes}, c.All())
+}
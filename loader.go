@@ -0,0 +1,112 @@
+package rgl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Collect GetPlayer calls made within wait of each other and send them as a single BulkPlayers request,
+// handing each caller its own player. IDs missing from the bulk response are treated as not found.
+func WithPlayerBatching(wait time.Duration) Option {
+	return func(r *RGL) {
+		r.players = &playerLoader{rgl: r, wait: wait}
+	}
+}
+
+// Dataloader-style batcher behind WithPlayerBatching
+type playerLoader struct {
+	rgl     *RGL
+	wait    time.Duration
+	mu      sync.Mutex
+	pending *playerBatch //Batch still accepting IDs, if any
+}
+
+type playerBatch struct {
+	ids     []SteamID
+	seen    map[SteamID]bool
+	once    sync.Once
+	done    chan struct{} //Closed once players and err are set
+	players map[SteamID][]byte
+	err     error
+}
+
+// Player json for steam64, from the cache or a batched request. Missing players give an error matching ErrNotFound.
+func (l *playerLoader) get(ctx context.Context, url string, steam64 SteamID) (io.ReadCloser, error) {
+	ttl := l.rgl.cacheTTL(ResourcePlayer)
+	if ttl > 0 && !bypassCache(ctx) {
+		if cached, ok := l.rgl.cached(url, ttl); ok {
+			return io.NopCloser(bytes.NewReader(cached)), nil
+		}
+	}
+
+	batch := l.add(steam64)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-batch.done:
+	}
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	raw, ok := batch.players[steam64]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s missing from bulk response", ErrNotFound, steam64)
+	}
+	if ttl > 0 {
+		l.rgl.cache.Set(url, raw, ttl)
+	}
+	return io.NopCloser(bytes.NewReader(raw)), nil
+}
+
+// Add an ID to the pending batch (starting one if needed) and return the batch it'll be fetched in
+func (l *playerLoader) add(steam64 SteamID) *playerBatch {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	batch := l.pending
+	if batch == nil {
+		batch = &playerBatch{seen: make(map[SteamID]bool), done: make(chan struct{})}
+		l.pending = batch
+		time.AfterFunc(l.wait, func() { l.flush(batch) })
+	}
+	if !batch.seen[steam64] {
+		batch.seen[steam64] = true
+		batch.ids = append(batch.ids, steam64)
+	}
+	if len(batch.ids) >= BULK_PLAYER_LIMIT {
+		l.pending = nil
+		go l.flush(batch)
+	}
+	return batch
+}
+
+// Send a batch. Runs without any caller's context, since it's shared; callers stop waiting on their own.
+func (l *playerLoader) flush(batch *playerBatch) {
+	batch.once.Do(func() {
+		l.mu.Lock()
+		if l.pending == batch {
+			l.pending = nil
+		}
+		l.mu.Unlock()
+
+		defer close(batch.done)
+		players, err := l.rgl.BulkPlayersCtx(context.Background(), batch.ids)
+		if err != nil {
+			batch.err = err
+			return
+		}
+		batch.players = make(map[SteamID][]byte, len(players))
+		for _, p := range players {
+			raw, err := json.Marshal(p)
+			if err != nil {
+				batch.err = fmt.Errorf("Error encoding player %s: %w", p.SteamId, err)
+				return
+			}
+			batch.players[p.SteamId] = raw
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package rgl
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPlayerBatching(t *testing.T) {
+	var calls int32
+	var requested []SteamID
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/profile/getmany", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		json.NewDecoder(req.Body).Decode(&requested)
+		fmt.Fprint(w, `[{"steamId": "76561198098770013", "name": "Captain Zidgel"}, {"steamId": "76561197970669109", "name": "b4nny"}]`)
+	})
+	mux.HandleFunc("/v0/profile/", func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("Unexpected single player request %s", req.URL.Path)
+	})
+	tr := newTestRGL(t, mux, WithPlayerBatching(50*time.Millisecond), WithNotFoundError())
+
+	ids := []SteamID{"76561198098770013", "76561197970669109", "76561198098770013", "76561198292350104"}
+	players := make([]Player, len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			players[i], errs[i] = tr.GetPlayer(ids[i])
+		}(i)
+	}
+	wg.Wait()
+
+	require.EqualValues(t, 1, calls, "Concurrent GetPlayer calls should be merged into one getmany")
+	require.ElementsMatch(t, []SteamID{"76561198098770013", "76561197970669109", "76561198292350104"}, requested, "Duplicate IDs should only be sent once")
+	require.NoError(t, errs[0])
+	require.Equal(t, "Captain Zidgel", players[0].Name)
+	require.Equal(t, "b4nny", players[1].Name)
+	require.Equal(t, "Captain Zidgel", players[2].Name)
+	require.ErrorIs(t, errs[3], ErrNotFound, "IDs missing from the bulk response should be not found")
+}
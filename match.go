@@ -0,0 +1,190 @@
+package rgl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Points as a number, like 2.75. "" (unplayed) is 0.
+func (t MatchTeam) PointsValue() (float64, error) {
+	p := strings.TrimSpace(t.Points)
+	if p == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(p, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Error parsing points %q: %w", t.Points, err)
+	}
+	return v, nil
+}
+
+// The team with id, if it's playing in this match
+func (m Match) Team(id int) (MatchTeam, bool) {
+	for _, t := range m.Teams {
+		if t.Id == id {
+			return t, true
+		}
+	}
+	return MatchTeam{}, false
+}
+
+// Index of the home team in m.Teams, or false if it can't be told. RGL often sends isHome false for both teams,
+// in which case the side is worked out from the winner: whichever side won more maps is the winner's.
+func (m Match) homeIndex() (int, bool) {
+	if len(m.Teams) != 2 {
+		return 0, false
+	}
+	for i, t := range m.Teams {
+		if t.IsHome {
+			return i, true
+		}
+	}
+	if m.WinnerId == 0 {
+		return 0, false
+	}
+	winner := -1
+	for i, t := range m.Teams {
+		if t.Id == m.WinnerId {
+			winner = i
+		}
+	}
+	homeMaps, awayMaps := m.MapWins()
+	switch {
+	case winner < 0 || homeMaps == awayMaps:
+		return 0, false
+	case homeMaps > awayMaps:
+		return winner, true
+	}
+	return 1 - winner, true
+}
+
+// The home team, whose score is MatchMap.HomeScore. false if it can't be told (see Match.homeIndex) or there aren't two teams.
+func (m Match) Home() (MatchTeam, bool) {
+	home, ok := m.homeIndex()
+	if !ok {
+		return MatchTeam{}, false
+	}
+	return m.Teams[home], true
+}
+
+// The away team, whose score is MatchMap.AwayScore. false if it can't be told or there aren't two teams.
+func (m Match) Away() (MatchTeam, bool) {
+	home, ok := m.homeIndex()
+	if !ok {
+		return MatchTeam{}, false
+	}
+	return m.Teams[1-home], true
+}
+
+// Number of maps won by each side, as MatchMap scores them. Tied maps count for neither.
+func (m Match) MapWins() (home int, away int) {
+	for _, mp := range m.Maps {
+		switch {
+		case mp.HomeScore > mp.AwayScore:
+			home++
+		case mp.AwayScore > mp.HomeScore:
+			away++
+		}
+	}
+	return home, away
+}
+
+// Maps won and lost by the team with id. false if it isn't playing or its side can't be told.
+func (m Match) TeamMapWins(id int) (won int, lost int, ok bool) {
+	home, ok := m.Home()
+	if !ok {
+		return 0, 0, false
+	}
+	away, _ := m.Away()
+	homeMaps, awayMaps := m.MapWins()
+	switch id {
+	case home.Id:
+		return homeMaps, awayMaps, true
+	case away.Id:
+		return awayMaps, homeMaps, true
+	}
+	return 0, 0, false
+}
+
+// Whether any map has a score
+func (m Match) hasScores() bool {
+	for _, mp := range m.Maps {
+		if mp.HomeScore != 0 || mp.AwayScore != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether any team was awarded points
+func (m Match) hasPoints() bool {
+	for _, t := range m.Teams {
+		if p, err := t.PointsValue(); err == nil && p != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the match has a result yet
+func (m Match) IsPlayed() bool {
+	return m.WinnerId != 0 || m.hasScores() || m.hasPoints()
+}
+
+// Whether the match was decided without being played: it has a result but every map score is 0
+func (m Match) IsForfeit() bool {
+	return m.IsPlayed() && !m.hasScores()
+}
+
+// The team that won, or false if the match is unplayed or tied.
+// RGL's winner is used when present. Otherwise it's whoever won more maps (best of 3 playoffs) if the sides are known,
+// then whoever got more points, which decides golden cap matches where the map score is level.
+func (m Match) Winner() (MatchTeam, bool) {
+	if m.WinnerId != 0 {
+		return m.Team(m.WinnerId)
+	}
+	if len(m.Teams) != 2 {
+		return MatchTeam{}, false
+	}
+	if home, ok := m.Home(); ok {
+		away, _ := m.Away()
+		homeMaps, awayMaps := m.MapWins()
+		switch {
+		case homeMaps > awayMaps:
+			return home, true
+		case awayMaps > homeMaps:
+			return away, true
+		}
+	}
+	a, b := m.Teams[0], m.Teams[1]
+	aPoints, err := a.PointsValue()
+	if err != nil {
+		return MatchTeam{}, false
+	}
+	bPoints, err := b.PointsValue()
+	if err != nil {
+		return MatchTeam{}, false
+	}
+	switch {
+	case aPoints > bPoints:
+		return a, true
+	case bPoints > aPoints:
+		return b, true
+	}
+	return MatchTeam{}, false
+}
+
+// The team that lost, or false if the match is unplayed or tied
+func (m Match) Loser() (MatchTeam, bool) {
+	winner, ok := m.Winner()
+	if !ok {
+		return MatchTeam{}, false
+	}
+	for _, t := range m.Teams {
+		if t.Id != winner.Id {
+			return t, true
+		}
+	}
+	return MatchTeam{}, false
+}
@@ -0,0 +1,91 @@
+package rgl
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// Match 5256 as RGL serves it: isHome is false for both teams, and the winner (listed first) played away
+const match5256 = `{
+  "matchId": 5256,
+  "seasonName": "Sixes S2",
+  "divName": "Intermediate",
+  "seasonId": 67,
+  "matchDate": "2020-01-15T03:30:00.000Z",
+  "matchName": "Week 1A",
+  "winner": 5979,
+  "teams": [
+    {"teamName": "nut.city", "teamTag": "nut.", "teamId": 5979, "isHome": false, "points": "2.75"},
+    {"teamName": "Sunny", "teamTag": "s.", "teamId": 5819, "isHome": false, "points": "0.25"}
+  ],
+  "maps": [{"mapName": "cp_snakewater_final1", "homeScore": 1, "awayScore": 5}]
+}`
+
+func TestMatchOutcome(t *testing.T) {
+	var m Match
+	require.NoError(t, json.Unmarshal([]byte(match5256), &m))
+	home, ok := m.Home()
+	require.True(t, ok)
+	require.Equal(t, 5819, home.Id, "Without isHome, the side should be worked out from the winner")
+	away, _ := m.Away()
+	require.Equal(t, 5979, away.Id)
+	won, lost, ok := m.TeamMapWins(5979)
+	require.True(t, ok)
+	require.Equal(t, 1, won)
+	require.Equal(t, 0, lost)
+
+	winner, ok := m.Winner()
+	require.True(t, ok)
+	require.Equal(t, 5979, winner.Id)
+	loser, _ := m.Loser()
+	require.Equal(t, 5819, loser.Id)
+	require.True(t, m.IsPlayed())
+	require.False(t, m.IsForfeit())
+	points, err := winner.PointsValue()
+	require.NoError(t, err)
+	require.Equal(t, 2.75, points)
+
+	m.WinnerId = 0
+	_, ok = m.Home()
+	require.False(t, ok, "Without isHome or a winner the sides can't be told")
+	_, _, ok = m.TeamMapWins(5979)
+	require.False(t, ok)
+	winner, ok = m.Winner()
+	require.True(t, ok)
+	require.Equal(t, 5979, winner.Id, "Points should decide the winner when the sides aren't known")
+}
+
+func TestMatchWinnerFallbacks(t *testing.T) {
+	teams := []MatchTeam{{Id: 1, Points: "0.25"}, {Id: 2, IsHome: true, Points: "2.75"}}
+
+	playoff := Match{Teams: teams, Maps: []MatchMap{{HomeScore: 5, AwayScore: 2}, {HomeScore: 1, AwayScore: 4}, {HomeScore: 3, AwayScore: 0}}}
+	home, ok := playoff.Home()
+	require.True(t, ok)
+	require.Equal(t, 2, home.Id)
+	homeMaps, awayMaps := playoff.MapWins()
+	require.Equal(t, 2, homeMaps)
+	require.Equal(t, 1, awayMaps)
+	winner, ok := playoff.Winner()
+	require.True(t, ok)
+	require.Equal(t, 2, winner.Id, "Best of 3 should go to whoever won more maps")
+
+	goldenCap := Match{Teams: teams, Maps: []MatchMap{{HomeScore: 4, AwayScore: 4}}}
+	winner, ok = goldenCap.Winner()
+	require.True(t, ok)
+	require.Equal(t, 2, winner.Id, "Level map scores should be decided by points")
+
+	forfeit := Match{Teams: []MatchTeam{{Id: 1, Points: "3"}, {Id: 2, Points: "0"}}, Maps: []MatchMap{{MapName: "koth_product_final"}}}
+	require.True(t, forfeit.IsForfeit())
+	winner, _ = forfeit.Winner()
+	require.Equal(t, 1, winner.Id)
+
+	unplayed := Match{Teams: []MatchTeam{{Id: 1}, {Id: 2}}, Maps: []MatchMap{{MapName: "koth_product_final"}}}
+	require.False(t, unplayed.IsPlayed())
+	require.False(t, unplayed.IsForfeit())
+	_, ok = unplayed.Winner()
+	require.False(t, ok)
+
+	_, err := MatchTeam{Points: "lots"}.PointsValue()
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package rgl
+
+import (
+	"golang.org/x/time/rate"
+	"net/http"
+	"strings"
+)
+
+// An Option configures an RGL instance created with New
+type Option func(*RGL)
+
+// Create an RGL instance. Without options this is the same as DefaultRateLimit(), but returns a pointer.
+func New(opts ...Option) *RGL {
+	r := &RGL{rl: defaultLimiter(), inflight: &flightGroup{calls: make(map[string]*flight)}}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Use a custom *http.Client for all requests (for custom transports, proxies, timeouts...)
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *RGL) {
+		r.client = client
+	}
+}
+
+// Send requests to a different base URL than RGL_ENDPOINT, ie a staging mirror or an httptest server.
+// The URL should include the API version, like "https://api.rgl.gg/v0/"
+func WithBaseURL(url string) Option {
+	return func(r *RGL) {
+		if !strings.HasSuffix(url, "/") {
+			url += "/"
+		}
+		r.baseURL = url
+	}
+}
+
+// Set the User-Agent header sent with every request
+func WithUserAgent(ua string) Option {
+	return func(r *RGL) {
+		r.userAgent = ua
+	}
+}
+
+// Replace the default ratelimiter. Pass nil to disable ratelimiting entirely (you will have to implement your own)
+func WithRateLimiter(rl *rate.Limiter) Option {
+	return func(r *RGL) {
+		r.rl = rl
+	}
+}
+
+// Make GetPlayer, GetTeam, GetSeason, GetMatch and GetPlayerTeamHistory return an error wrapping ErrNotFound
+// when the entity doesn't exist, instead of a zero value and nil error. Searches and bulk lookups still return empty results.
+func WithNotFoundError() Option {
+	return func(r *RGL) {
+		r.notFoundErrors = true
+	}
+}
@@ -0,0 +1,84 @@
+package rgl
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Create an unlimited RGL instance pointed at a local server
+func newTestRGL(t *testing.T, handler http.Handler, opts ...Option) *RGL {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	opts = append([]Option{WithBaseURL(srv.URL + "/v0"), WithRateLimiter(nil)}, opts...)
+	return New(opts...)
+}
+
+func TestNew(t *testing.T) {
+	r := New()
+	require.NotNil(t, r.rl, "Should use the default ratelimiter")
+	require.Equal(t, PLAYER_ENDPOINT+"123", r.endpoint(playerPath+"123"), "Should use RGL_ENDPOINT by default")
+
+	r = New(WithRateLimiter(nil), WithBaseURL("http://localhost:8080/v0"))
+	require.Nil(t, r.rl)
+	require.Equal(t, "http://localhost:8080/v0/teams/5", r.endpoint(teamPath+"5"))
+}
+
+func TestOptions(t *testing.T) {
+	var gotUA, gotPath string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/teams/", func(w http.ResponseWriter, req *http.Request) {
+		gotUA = req.Header.Get("User-Agent")
+		gotPath = req.URL.Path
+		fmt.Fprint(w, `{"teamId": 5979, "name": "nut.city"}`)
+	})
+
+	var used bool
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		used = true
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	tr := newTestRGL(t, mux, WithUserAgent("rgl-test/1.0"), WithHTTPClient(client))
+
+	team, err := tr.GetTeam(5979)
+	require.NoError(t, err)
+	require.Equal(t, "nut.city", team.Name)
+	require.Equal(t, "rgl-test/1.0", gotUA, "Should send configured user agent")
+	require.Equal(t, "/v0/teams/5979", gotPath, "Should request against the configured base URL")
+	require.True(t, used, "Should send requests with the configured client")
+}
+
+func TestWithNotFoundError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	tr := newTestRGL(t, mux)
+	team, err := tr.GetTeam(111111)
+	require.NoError(t, err, "Default should swallow 404")
+	require.Equal(t, Team{}, team)
+
+	tr = newTestRGL(t, mux, WithNotFoundError())
+	_, err = tr.GetTeam(111111)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = tr.GetPlayer("76561198098770013")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = tr.GetSeason(11111)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = tr.GetMatch(555555)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = tr.GetPlayerTeamHistory("76561198098770013")
+	require.ErrorIs(t, err, ErrNotFound)
+	players, err := tr.BulkPlayers([]SteamID{"76561198098770013"})
+	require.NoError(t, err, "Bulk lookups should still return empty results")
+	require.Len(t, players, 0)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
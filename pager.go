@@ -0,0 +1,99 @@
+package rgl
+
+import "context"
+
+// Walks a paginated endpoint one page at a time:
+//
+//	p := r.BansPager(100)
+//	for p.Next(ctx) {
+//		for _, ban := range p.Page() { ... }
+//	}
+//	if err := p.Err(); err != nil { ... }
+//
+// Every page is a normal request, so it waits on the ratelimiter and is bound to the ctx passed to Next.
+type Pager[T any] struct {
+	fetch    func(ctx context.Context, take int, skip int) (page []T, total int, err error) //total is -1 if unknown
+	pageSize int
+	skip     int
+	page     []T
+	err      error
+	done     bool
+}
+
+// Create a Pager over any take/skip endpoint. fetch returns one page and the total number of items, or -1 if the endpoint doesn't say.
+// A pageSize below 1 is treated as 1.
+func NewPager[T any](pageSize int, fetch func(ctx context.Context, take int, skip int) ([]T, int, error)) *Pager[T] {
+	if pageSize < 1 {
+		pageSize = 1 //Otherwise a short page never looks like the last one
+	}
+	return &Pager[T]{fetch: fetch, pageSize: pageSize}
+}
+
+// Fetch the next page. Returns false when there are no more pages or a request failed (check Err).
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.done {
+		return false
+	}
+	page, total, err := p.fetch(ctx, p.pageSize, p.skip)
+	if err != nil {
+		p.err = err
+		p.done = true
+		p.page = nil
+		return false
+	}
+	p.skip += len(page)
+	p.page = page
+	if len(page) == 0 {
+		p.done = true
+		return false
+	}
+	//This may be the last page, but it still has to be returned. With a known total, a short page isn't the end
+	//(RGL may cap take below pageSize), only reaching the total is.
+	if (total >= 0 && p.skip >= total) || (total < 0 && len(page) < p.pageSize) {
+		p.done = true
+	}
+	return true
+}
+
+// The page fetched by the last call to Next
+func (p *Pager[T]) Page() []T {
+	return p.page
+}
+
+// The error that stopped the Pager, if any
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// Fetch every remaining page and return all their items together
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	all := make([]T, 0)
+	for p.Next(ctx) {
+		all = append(all, p.Page()...)
+	}
+	return all, p.Err()
+}
+
+// Page through every SearchPlayers result for alias, pageSize at a time
+func (rgl *RGL) SearchPlayersPager(alias string, pageSize int) *Pager[SteamID] {
+	return NewPager(pageSize, func(ctx context.Context, take int, skip int) ([]SteamID, int, error) {
+		results, err := rgl.SearchPlayersCtx(ctx, alias, take, skip)
+		return results.Results, results.TotalHitCount, err
+	})
+}
+
+// Page through every SearchTeams result for partial, pageSize at a time
+func (rgl *RGL) SearchTeamsPager(partial string, pageSize int) *Pager[int] {
+	return NewPager(pageSize, func(ctx context.Context, take int, skip int) ([]int, int, error) {
+		results, err := rgl.SearchTeamsCtx(ctx, partial, take, skip)
+		return results.Results, results.TotalHitCount, err
+	})
+}
+
+// Page through the entire ban history (newest first), pageSize at a time
+func (rgl *RGL) BansPager(pageSize int) *Pager[BulkBan] {
+	return NewPager(pageSize, func(ctx context.Context, take int, skip int) ([]BulkBan, int, error) {
+		bans, err := rgl.GetBansCtx(ctx, take, skip)
+		return bans, -1, err
+	})
+}
@@ -0,0 +1,108 @@
+package rgl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// Serve a take/skip endpoint over n items
+func pagedHandler(n int, write func(w http.ResponseWriter, items []int)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		take, _ := strconv.Atoi(req.URL.Query().Get("take"))
+		skip, _ := strconv.Atoi(req.URL.Query().Get("skip"))
+		items := make([]int, 0)
+		for i := skip; i < skip+take && i < n; i++ {
+			items = append(items, i)
+		}
+		write(w, items)
+	}
+}
+
+func TestPagers(t *testing.T) {
+	var searches int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/search/teams", pagedHandler(25, func(w http.ResponseWriter, items []int) {
+		searches++
+		results := make([]string, len(items))
+		for i, item := range items {
+			results[i] = fmt.Sprint(item)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results, "count": len(results), "totalHitCount": 25})
+	}))
+	mux.HandleFunc("/v0/bans/paged", pagedHandler(7, func(w http.ResponseWriter, items []int) {
+		bans := make([]BulkBan, len(items))
+		for i, item := range items {
+			bans[i].SteamId = SteamID(fmt.Sprint(item))
+		}
+		json.NewEncoder(w).Encode(bans)
+	}))
+	tr := newTestRGL(t, mux)
+	ctx := context.Background()
+
+	p := tr.SearchTeamsPager("froyo", 10)
+	pages := 0
+	all := make([]int, 0)
+	for p.Next(ctx) {
+		pages++
+		all = append(all, p.Page()...)
+	}
+	require.NoError(t, p.Err())
+	require.Equal(t, 3, pages)
+	require.Len(t, all, 25)
+	require.Equal(t, 24, all[24])
+	require.Equal(t, 3, searches, "Should stop once TotalHitCount is reached, without fetching an empty page")
+
+	bans, err := tr.BansPager(5).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, bans, 7, "Should stop when bans run out")
+
+	bans, err = tr.BansPager(7).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, bans, 7, "Should handle the last page being full")
+
+	_, err = tr.SearchPlayersPager("a", 10).All(ctx)
+	require.Error(t, err, "Should surface request errors")
+}
+
+func TestPagerPageSize(t *testing.T) {
+	takes := make([]int, 0)
+	p := NewPager(0, func(ctx context.Context, take int, skip int) ([]int, int, error) {
+		takes = append(takes, take)
+		if skip >= 3 || len(takes) > 10 {
+			return nil, -1, nil
+		}
+		page := make([]int, 0, take)
+		for i := skip; i < skip+take && i < 3; i++ {
+			page = append(page, i)
+		}
+		return page, -1, nil
+	})
+	all, err := p.All(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2}, all)
+	require.Equal(t, []int{1, 1, 1, 1}, takes, "A page size of 0 should be raised to 1")
+}
+
+func TestPagerCappedTake(t *testing.T) {
+	const capped = 4 //The server never returns more than this, whatever take is
+	var searches int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/search/teams", func(w http.ResponseWriter, req *http.Request) {
+		searches++
+		skip, _ := strconv.Atoi(req.URL.Query().Get("skip"))
+		results := make([]string, 0)
+		for i := skip; i < skip+capped && i < 10; i++ {
+			results = append(results, fmt.Sprint(i))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results, "count": len(results), "totalHitCount": 10})
+	})
+	all, err := newTestRGL(t, mux).SearchTeamsPager("froyo", 25).All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, all, 10, "Short pages shouldn't stop the pager before TotalHitCount")
+	require.Equal(t, 3, searches)
+}
@@ -0,0 +1,114 @@
+package rgl
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/time/rate"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Use a separate ratelimiter for POST search endpoints (search/players, search/teams, profile/getmany),
+// so searches and plain GETs each get their own budget. Without this, both share the main ratelimiter.
+func WithSearchRateLimiter(rl *rate.Limiter) Option {
+	return func(r *RGL) {
+		r.searchRL = rl
+	}
+}
+
+// Adjust the ratelimiters from RGL's X-RateLimit-Remaining/X-RateLimit-Reset (and X-RateLimit-Limit) response headers,
+// spreading the remaining budget over the rest of the window and pausing until the reset when it runs out.
+// The configured limiters are only a starting point, so RGL can change its limits without a code change here.
+func WithAdaptiveRateLimit() Option {
+	return func(r *RGL) {
+		r.adaptive = &adaptiveLimits{resume: make(map[*rate.Limiter]time.Time)}
+	}
+}
+
+// The limiter that should be waited on for a request with this method, or nil
+func (rgl *RGL) limiter(method string) *rate.Limiter {
+	if method == http.MethodPost && rgl.searchRL != nil {
+		return rgl.searchRL
+	}
+	return rgl.rl
+}
+
+// Wait on the limiter for this method (and any pause the server asked for)
+func (rgl *RGL) wait(ctx context.Context, method string) error {
+	lim := rgl.limiter(method)
+	if lim == nil { //If using the pkgs ratelimiter (user should implement their own if they don't want to use the default)
+		return nil
+	}
+	if rgl.adaptive != nil {
+		if err := sleepCtx(ctx, rgl.adaptive.pause(lim, time.Now())); err != nil {
+			return fmt.Errorf("Error waiting on ratelimiter: %w", err)
+		}
+	}
+	if err := lim.Wait(ctx); err != nil {
+		return fmt.Errorf("Error waiting on ratelimiter: %w", err)
+	}
+	return nil
+}
+
+// Feed a response's headers back into the limiter for this method, if adaptive ratelimiting is on
+func (rgl *RGL) observe(method string, resp *http.Response) {
+	lim := rgl.limiter(method)
+	if rgl.adaptive == nil || lim == nil {
+		return
+	}
+	rgl.adaptive.observe(lim, resp, time.Now())
+}
+
+// Server-driven state for WithAdaptiveRateLimit
+type adaptiveLimits struct {
+	mu     sync.Mutex
+	resume map[*rate.Limiter]time.Time //Don't use a limiter again until this time
+}
+
+// How long to wait before using lim
+func (a *adaptiveLimits) pause(lim *rate.Limiter, now time.Time) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.resume[lim].Sub(now)
+}
+
+func (a *adaptiveLimits) observe(lim *rate.Limiter, resp *http.Response, now time.Time) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := retryAfter(resp.Header, now); ok {
+			a.pauseUntil(lim, now.Add(wait))
+			return
+		}
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, ok := rateLimitReset(resp.Header, now)
+	if !ok {
+		return
+	}
+	if remaining <= 0 {
+		a.pauseUntil(lim, reset)
+		return
+	}
+	window := reset.Sub(now)
+	if window <= 0 {
+		return
+	}
+	lim.SetLimitAt(now, rate.Limit(float64(remaining)/window.Seconds()))
+	burst := remaining
+	if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil && limit > 0 && limit < burst {
+		burst = limit
+	}
+	lim.SetBurstAt(now, burst)
+}
+
+func (a *adaptiveLimits) pauseUntil(lim *rate.Limiter, t time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if t.After(a.resume[lim]) {
+		a.resume[lim] = t
+	}
+}
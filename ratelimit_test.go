@@ -0,0 +1,57 @@
+package rgl
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveLimits(t *testing.T) {
+	now := time.Date(2023, 2, 12, 0, 0, 0, 0, time.UTC)
+	a := &adaptiveLimits{resume: make(map[*rate.Limiter]time.Time)}
+	lim := rate.NewLimiter(rate.Every(time.Second), 2)
+
+	resp := &http.Response{StatusCode: 200, Header: http.Header{}}
+	resp.Header.Set("X-RateLimit-Limit", "30")
+	resp.Header.Set("X-RateLimit-Remaining", "20")
+	resp.Header.Set("X-RateLimit-Reset", "10")
+	a.observe(lim, resp, now)
+	require.Equal(t, rate.Limit(2), lim.Limit(), "Should spread the remaining requests over the window")
+	require.Equal(t, 20, lim.Burst())
+	require.LessOrEqual(t, a.pause(lim, now), time.Duration(0), "Shouldn't pause while requests remain")
+
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	a.observe(lim, resp, now)
+	require.Equal(t, 10*time.Second, a.pause(lim, now), "Should pause until the window resets")
+
+	resp = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "30")
+	a.observe(lim, resp, now)
+	require.Equal(t, 30*time.Second, a.pause(lim, now), "Should pause for Retry-After on 429")
+}
+
+func TestSearchRateLimiter(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/search/teams", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"results": [], "count": 0, "totalHitCount": 0}`)
+	})
+	mux.HandleFunc("/v0/teams/5979", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"teamId": 5979}`)
+	})
+	getRL := rate.NewLimiter(rate.Every(time.Hour), 1)
+	searchRL := rate.NewLimiter(rate.Every(time.Hour), 1)
+	tr := newTestRGL(t, mux, WithRateLimiter(getRL), WithSearchRateLimiter(searchRL), WithAdaptiveRateLimit())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := tr.SearchTeamsCtx(ctx, "froyo", 1, 0)
+	require.NoError(t, err)
+	_, err = tr.GetTeamCtx(ctx, 5979)
+	require.NoError(t, err, "Search shouldn't spend the GET budget")
+	require.False(t, searchRL.Allow(), "Search should spend the search budget")
+	require.False(t, getRL.Allow(), "GET should spend the GET budget")
+}
@@ -0,0 +1,128 @@
+package rgl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Most GetTeam requests SearchTeamsResolved makes at once. They still all wait on the ratelimiter.
+const RESOLVE_CONCURRENCY = 4
+
+// Like SearchPlayers, but returns the full players (via BulkPlayers) in search order instead of their Steam64s
+func (rgl *RGL) SearchPlayersResolved(ctx context.Context, alias string, take int, skip int) ([]Player, error) {
+	results, err := rgl.SearchPlayersCtx(ctx, alias, take, skip)
+	if err != nil {
+		return nil, err
+	}
+	found, err := rgl.BulkPlayersCtx(ctx, results.Results)
+	if err != nil {
+		return nil, fmt.Errorf("Error resolving search results: %w", err)
+	}
+	byId := make(map[SteamID]Player, len(found))
+	for _, p := range found {
+		byId[p.SteamId] = p
+	}
+	players := make([]Player, 0, len(found))
+	for _, id := range results.Results {
+		if p, ok := byId[id]; ok {
+			players = append(players, p)
+		}
+	}
+	return players, nil
+}
+
+// Like SearchTeams, but returns the full teams (via GetTeam, RESOLVE_CONCURRENCY at a time) in search order instead of their IDs
+func (rgl *RGL) SearchTeamsResolved(ctx context.Context, partial string, take int, skip int) ([]Team, error) {
+	results, err := rgl.SearchTeamsCtx(ctx, partial, take, skip)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := rgl.getTeams(ctx, results.Results)
+	if err != nil {
+		return nil, fmt.Errorf("Error resolving search results: %w", err)
+	}
+	return teams, nil
+}
+
+// GetTeam for each id (RESOLVE_CONCURRENCY at a time), in order. Teams that don't exist are left out.
+func (rgl *RGL) getTeams(ctx context.Context, ids []int) ([]Team, error) {
+	fetched := make([]Team, len(ids))
+	err := forEachLimit(ctx, len(ids), RESOLVE_CONCURRENCY, func(ctx context.Context, i int) error {
+		var err error
+		fetched[i], err = rgl.GetTeamCtx(ctx, ids[i])
+		if errors.Is(err, ErrNotFound) { //Left out, even with WithNotFoundError
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	teams := make([]Team, 0, len(ids))
+	for _, t := range fetched {
+		if t.Id > 0 {
+			teams = append(teams, t)
+		}
+	}
+	return teams, nil
+}
+
+// Call fn for 0 <= i < n, with at most limit calls running at once. Stops at (and returns) the first error.
+func forEachLimit(ctx context.Context, n int, limit int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, i); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// GetMatch for each id (RESOLVE_CONCURRENCY at a time), in order. Matches that don't exist are left out.
+func (rgl *RGL) getMatches(ctx context.Context, ids []int) ([]Match, error) {
+	fetched := make([]Match, len(ids))
+	err := forEachLimit(ctx, len(ids), RESOLVE_CONCURRENCY, func(ctx context.Context, i int) error {
+		var err error
+		fetched[i], err = rgl.GetMatchCtx(ctx, ids[i])
+		if errors.Is(err, ErrNotFound) { //Left out, even with WithNotFoundError
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]Match, 0, len(ids))
+	for _, m := range fetched {
+		if m.Id > 0 {
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,61 @@
+package rgl
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSearchResolved(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/search/players", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"results": ["76561197970669109", "76561198098770013"], "count": 2, "totalHitCount": 2}`)
+	})
+	mux.HandleFunc("/v0/profile/getmany", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `[{"steamId": "76561198098770013", "name": "Captain Zidgel"}, {"steamId": "76561197970669109", "name": "b4nny"}]`)
+	})
+	mux.HandleFunc("/v0/search/teams", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"results": ["42", "83", "404", "1142"], "count": 4, "totalHitCount": 4}`)
+	})
+	var running, maxRunning int32
+	mux.HandleFunc("/v0/teams/", func(w http.ResponseWriter, req *http.Request) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		id := strings.TrimPrefix(req.URL.Path, "/v0/teams/")
+		if id == "404" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"teamId": %s, "name": "team %s"}`, id, id)
+	})
+	tr := newTestRGL(t, mux)
+	ctx := context.Background()
+
+	players, err := tr.SearchPlayersResolved(ctx, "b4", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, players, 2)
+	require.Equal(t, "b4nny", players[0].Name, "Should keep search order")
+	require.Equal(t, "Captain Zidgel", players[1].Name)
+
+	teams, err := tr.SearchTeamsResolved(ctx, "froyo", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, teams, 3, "Teams that 404 should be left out")
+	require.Equal(t, []string{"team 42", "team 83", "team 1142"}, []string{teams[0].Name, teams[1].Name, teams[2].Name})
+	require.LessOrEqual(t, int(maxRunning), RESOLVE_CONCURRENCY)
+
+	teams, err = newTestRGL(t, mux, WithNotFoundError()).SearchTeamsResolved(ctx, "froyo", 10, 0)
+	require.NoError(t, err, "Teams that 404 should be left out with WithNotFoundError too")
+	require.Len(t, teams, 3)
+}
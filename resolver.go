@@ -0,0 +1,106 @@
+package rgl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Turns Steam vanity names (the <vanity> in steamcommunity.com/id/<vanity>) into Steam64s.
+// Set one with WithSteamResolver so methods taking a SteamID also accept vanity URLs.
+type SteamResolver interface {
+	ResolveVanity(ctx context.Context, vanity string) (SteamID, error)
+}
+
+// Resolve vanity URLs with resolver wherever a SteamID is accepted
+func WithSteamResolver(resolver SteamResolver) Option {
+	return func(r *RGL) {
+		r.resolver = resolver
+	}
+}
+
+const STEAM_API_ENDPOINT = "https://api.steampowered.com/"
+
+// A SteamResolver using the Steam Web API's ISteamUser/ResolveVanityURL. Needs a Steam Web API key.
+type SteamWebAPIResolver struct {
+	Key     string
+	BaseURL string       // Defaults to STEAM_API_ENDPOINT. Point it at a local stub for testing
+	Client  *http.Client // Defaults to http.DefaultClient
+}
+
+// Create a SteamWebAPIResolver against the real Steam Web API
+func NewSteamWebAPIResolver(key string) *SteamWebAPIResolver {
+	return &SteamWebAPIResolver{Key: key, BaseURL: STEAM_API_ENDPOINT}
+}
+
+// Look up vanity. Names without a matching profile give an error wrapping ErrNotFound.
+func (s *SteamWebAPIResolver) ResolveVanity(ctx context.Context, vanity string) (SteamID, error) {
+	base := s.BaseURL
+	if base == "" {
+		base = STEAM_API_ENDPOINT
+	}
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	query := url.Values{"key": {s.Key}, "vanityurl": {vanity}, "url_type": {"1"}}
+	endpoint := base + "ISteamUser/ResolveVanityURL/v1/?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("Error creating vanity request: %w", err)
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Error resolving vanity %q: %w", vanity, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Error resolving vanity %q: steam responded %d", vanity, resp.StatusCode)
+	}
+	var body struct {
+		Response struct {
+			Success int    `json:"success"`
+			SteamId string `json:"steamid"`
+			Message string `json:"message"`
+		} `json:"response"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("Error decoding vanity response: %w", err)
+	}
+	if body.Response.Success != 1 { //42 means no match
+		return "", fmt.Errorf("%w: vanity %q (%s)", ErrNotFound, vanity, body.Response.Message)
+	}
+	return ParseSteamID(body.Response.SteamId)
+}
+
+// Like ParseSteamID, but vanity URLs are resolved with the configured SteamResolver
+func (rgl *RGL) ResolveSteamID(ctx context.Context, s string) (SteamID, error) {
+	id, err := ParseSteamID(s)
+	if !errors.Is(err, ErrVanityURL) || rgl.resolver == nil {
+		return id, err
+	}
+	vanity, _ := vanityName(s)
+	id, err = rgl.resolver.ResolveVanity(ctx, vanity)
+	if err != nil {
+		return "", fmt.Errorf("Error resolving vanity URL: %w", err)
+	}
+	return id, nil
+}
+
+// The vanity name in a steamcommunity.com/id/<vanity> link
+func vanityName(s string) (string, bool) {
+	_, path, ok := strings.Cut(s, "steamcommunity.com/id/")
+	if !ok {
+		return "", false
+	}
+	name, _, _ := strings.Cut(path, "/")
+	name, _, _ = strings.Cut(name, "?")
+	return name, name != ""
+}
@@ -0,0 +1,52 @@
+package rgl
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSteamWebAPIResolver(t *testing.T) {
+	steam := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/ISteamUser/ResolveVanityURL/v1/", req.URL.Path)
+		require.Equal(t, "testkey", req.URL.Query().Get("key"))
+		if req.URL.Query().Get("vanityurl") == "captainzidgel" {
+			fmt.Fprint(w, `{"response": {"steamid": "76561198098770013", "success": 1}}`)
+			return
+		}
+		fmt.Fprint(w, `{"response": {"success": 42, "message": "No match"}}`)
+	}))
+	defer steam.Close()
+	resolver := &SteamWebAPIResolver{Key: "testkey", BaseURL: steam.URL}
+	ctx := context.Background()
+
+	id, err := resolver.ResolveVanity(ctx, "captainzidgel")
+	require.NoError(t, err)
+	require.Equal(t, SteamID("76561198098770013"), id)
+
+	_, err = resolver.ResolveVanity(ctx, "nobody")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	var requested string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/profile/", func(w http.ResponseWriter, req *http.Request) {
+		requested = req.URL.Path
+		fmt.Fprint(w, `{"steamId": "76561198098770013", "name": "Captain Zidgel"}`)
+	})
+	tr := newTestRGL(t, mux, WithSteamResolver(resolver))
+
+	p, err := tr.GetPlayer("https://steamcommunity.com/id/captainzidgel/")
+	require.NoError(t, err)
+	require.Equal(t, "Captain Zidgel", p.Name)
+	require.Equal(t, "/v0/profile/76561198098770013", requested, "Should request the resolved Steam64")
+
+	id, err = tr.ResolveSteamID(ctx, "STEAM_0:1:69252142")
+	require.NoError(t, err, "Non-vanity IDs shouldn't need the resolver")
+	require.Equal(t, SteamID("76561198098770013"), id)
+
+	_, err = newTestRGL(t, mux).GetPlayer("https://steamcommunity.com/id/captainzidgel/")
+	require.ErrorIs(t, err, ErrVanityURL, "Vanity URLs need a resolver")
+}
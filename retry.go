@@ -0,0 +1,115 @@
+package rgl
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Controls how failed requests are retried. Only network errors, 429s and 5xx responses are retried.
+// The zero value never retries.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first one. 1 or less disables retrying
+	BaseDelay   time.Duration // Delay before the first retry, doubled for each one after
+	MaxDelay    time.Duration // Cap on any single delay, including ones requested by the server. 0 means no cap
+	Jitter      float64       // Randomize each backoff delay by up to this fraction of itself (0.2 = ±20%)
+}
+
+// A reasonable policy for RGL's flaky alpha API: 4 attempts, starting at 1 second, capped at 30 seconds.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   time.Second,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// Retry failed requests according to policy. Only idempotent requests are retried: GETs, and the POSTs
+// that are read-only lookups (player/team search and BulkPlayers).
+func WithRetry(policy RetryPolicy) Option {
+	return func(r *RGL) {
+		r.retry = policy
+	}
+}
+
+// How long to wait after the given (1-indexed) failed attempt. A positive serverWait (from Retry-After etc)
+// takes precedence over exponential backoff.
+func (p RetryPolicy) delay(attempt int, serverWait time.Duration) time.Duration {
+	d := serverWait
+	if d <= 0 {
+		d = time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt-1)))
+		if p.Jitter > 0 {
+			d += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(d))
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d < 0 {
+		d = 0
+	}
+	return d
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// How long the server asked us to wait, from Retry-After, or X-RateLimit-Reset when X-RateLimit-Remaining is 0
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return t.Sub(now), true
+		}
+	}
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, ok := rateLimitReset(h, now); ok {
+			return reset.Sub(now), true
+		}
+	}
+	return 0, false
+}
+
+// When the current ratelimit window resets, from X-RateLimit-Reset.
+// Accepts either a unix timestamp or a number of seconds from now.
+func rateLimitReset(h http.Header, now time.Time) (time.Time, bool) {
+	v := h.Get("X-RateLimit-Reset")
+	if v == "" {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if secs > 1e9 { //Too big to be a delay, must be a timestamp
+		return time.Unix(0, int64(secs*float64(time.Second))), true
+	}
+	return now.Add(time.Duration(secs * float64(time.Second))), true
+}
+
+// Sleep for d, returning early with ctx's error if it's cancelled
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,99 @@
+package rgl
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	var calls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/teams/5979", func(w http.ResponseWriter, req *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"teamId": 5979}`)
+		}
+	})
+	mux.HandleFunc("/v0/teams/1", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	mux.HandleFunc("/v0/search/teams", func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1)%2 == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"results": ["5979"], "count": 1, "totalHitCount": 1}`)
+	})
+
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	tr := newTestRGL(t, mux, WithRetry(policy))
+
+	team, err := tr.GetTeam(5979)
+	require.NoError(t, err, "Should succeed on the third attempt")
+	require.Equal(t, 5979, team.Id)
+	require.EqualValues(t, 3, calls)
+
+	_, err = tr.GetTeam(1)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr, "Should give up after MaxAttempts with the last error")
+	require.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = tr.SearchTeams("nut", 1, 0)
+	require.NoError(t, err, "Read-only POSTs like searches should be retried too")
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = tr.post(context.Background(), tr.endpoint(searchTeamPath), nil, false)
+	require.Error(t, err, "POSTs that aren't marked read-only shouldn't be retried")
+	require.EqualValues(t, 1, calls)
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = tr.send(context.Background(), http.MethodGet, tr.endpoint("teams/5979"), nil, false)
+	require.Error(t, err, "Non-idempotent requests shouldn't be retried")
+	require.EqualValues(t, 1, calls)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2023, 2, 12, 0, 0, 0, 0, time.UTC)
+
+	h := http.Header{}
+	h.Set("Retry-After", "5")
+	d, ok := retryAfter(h, now)
+	require.True(t, ok)
+	require.Equal(t, 5*time.Second, d)
+
+	h = http.Header{}
+	h.Set("Retry-After", now.Add(10*time.Second).Format(http.TimeFormat))
+	d, _ = retryAfter(h, now)
+	require.Equal(t, 10*time.Second, d, "Should accept HTTP dates")
+
+	h = http.Header{}
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", fmt.Sprint(now.Add(3*time.Second).Unix()))
+	d, _ = retryAfter(h, now)
+	require.Equal(t, 3*time.Second, d, "Should accept a unix timestamp reset")
+
+	h.Set("X-RateLimit-Reset", "2")
+	d, _ = retryAfter(h, now)
+	require.Equal(t, 2*time.Second, d, "Should accept a relative reset")
+
+	h.Set("X-RateLimit-Remaining", "1")
+	_, ok = retryAfter(h, now)
+	require.False(t, ok, "Shouldn't wait for reset when there are requests remaining")
+
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+	require.Equal(t, time.Second, p.delay(1, 0))
+	require.Equal(t, 2*time.Second, p.delay(2, 0))
+	require.Equal(t, 3*time.Second, p.delay(3, 0), "Should be capped at MaxDelay")
+	require.Equal(t, 3*time.Second, p.delay(1, time.Minute), "Server delays should be capped too")
+}
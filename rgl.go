@@ -6,22 +6,36 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"golang.org/x/time/rate"
 	"io"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 )
 
 const RGL_ENDPOINT = "https://api.rgl.gg/v0/"
-const PLAYER_ENDPOINT = RGL_ENDPOINT + "profile/"
-const TEAM_ENDPOINT = RGL_ENDPOINT + "teams/"
-const SEASON_ENDPOINT = RGL_ENDPOINT + "seasons/"
-const SEARCH_ALIAS_ENDPOINT = RGL_ENDPOINT + "search/players"
-const BULK_PLAYER_ENDPOINT = PLAYER_ENDPOINT + "getmany"
-const MATCH_ENDPOINT = RGL_ENDPOINT + "matches/"
-const SEARCH_TEAM_ENDPOINT = RGL_ENDPOINT + "search/teams"
+const PLAYER_ENDPOINT = RGL_ENDPOINT + playerPath
+const TEAM_ENDPOINT = RGL_ENDPOINT + teamPath
+const SEASON_ENDPOINT = RGL_ENDPOINT + seasonPath
+const SEARCH_ALIAS_ENDPOINT = RGL_ENDPOINT + searchAliasPath
+const BULK_PLAYER_ENDPOINT = RGL_ENDPOINT + bulkPlayerPath
+const MATCH_ENDPOINT = RGL_ENDPOINT + matchPath
+const SEARCH_TEAM_ENDPOINT = RGL_ENDPOINT + searchTeamPath
+const BANS_ENDPOINT = RGL_ENDPOINT + bansPath
+
+// Paths relative to the base URL, so the base can be swapped out with WithBaseURL
+const (
+	playerPath      = "profile/"
+	teamPath        = "teams/"
+	seasonPath      = "seasons/"
+	searchAliasPath = "search/players"
+	bulkPlayerPath  = playerPath + "getmany"
+	matchPath       = "matches/"
+	searchTeamPath  = "search/teams"
+	bansPath        = "bans/paged"
+)
 
 // Used in Player.CurrentTeams to represent the teams a player is on
 type CurrTeam struct {
@@ -43,17 +57,17 @@ type PlayerStatus struct {
 
 // Used in Player to represent ban information
 type Ban struct {
-	Ends   string `json:"endsAt"`
+	Ends   Time   `json:"endsAt"`
 	Reason string `json:"reason"`
 }
 
 // Used when receiving paginated bans
 type BulkBan struct {
-	SteamId string `json:"steamId"`
-	Alias   string `json:"alias"`
-	Expires string `json:"expiresAt"`
-	Created string `json:"createdAt"`
-	Reason  string `json:"reason"`
+	SteamId SteamID `json:"steamId"`
+	Alias   string  `json:"alias"`
+	Expires Time    `json:"expiresAt"`
+	Created Time    `json:"createdAt"`
+	Reason  string  `json:"reason"`
 }
 
 // Used in Player to represent the teams a player is on in each format
@@ -65,29 +79,63 @@ type CurrentTeams struct {
 
 // Toplevel endpoint for a player found by id
 type Player struct {
-	SteamId      string       `json:"steamId"`
+	SteamId      SteamID      `json:"steamId"`
 	Avatar       string       `json:"avatar"`
 	Name         string       `json:"name"`
-	Updated      string       `json:"updatedAt"`
+	Updated      Time         `json:"updatedAt"`
 	Status       PlayerStatus `json:"status"`
 	Ban          *Ban         `json:"banInformation"`
 	CurrentTeams CurrentTeams `json:"currentTeams"`
 }
 
-// To check if this is empty, use len(SearchResults.Results) == 0 instead of equality checking == SearchResults{}
-// (If you want to equality check the results of rgl.SearchPlayers, you'd have to use SearchResults{Results: make([]string, 0)}
+// The raw shape of search responses, with IDs as strings. SearchPlayers and SearchTeams decode into
+// PlayerSearchResults and TeamSearchResults instead, which have properly typed IDs.
 type SearchResults struct {
 	Results       []string `json:"results"` //A slice of Steam64 IDs (Steam64s for Players, RGL Team IDs for Teams)
-	Count         int      `json:"int"`
+	Count         int      `json:"count"`
 	TotalHitCount int      `json:"totalHitCount"`
 }
 
+// Results of SearchPlayers. The Results can be passed straight to BulkPlayers.
+// To check if this is empty, use len(results.Results) == 0 instead of equality checking == PlayerSearchResults{}
+type PlayerSearchResults struct {
+	Results       []SteamID `json:"results"`
+	Count         int       `json:"count"` //Number of results in this page
+	TotalHitCount int       `json:"totalHitCount"`
+}
+
+// Results of SearchTeams. RGL sends the team IDs as strings, they're decoded to ints so they can be passed straight to GetTeam.
+// To check if this is empty, use len(results.Results) == 0 instead of equality checking == TeamSearchResults{}
+type TeamSearchResults struct {
+	Results       []int `json:"results"`
+	Count         int   `json:"count"` //Number of results in this page
+	TotalHitCount int   `json:"totalHitCount"`
+}
+
+func (r *TeamSearchResults) UnmarshalJSON(data []byte) error {
+	var raw SearchResults
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.Count = raw.Count
+	r.TotalHitCount = raw.TotalHitCount
+	r.Results = make([]int, len(raw.Results))
+	for i, s := range raw.Results {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("Invalid team id %q in search results", s)
+		}
+		r.Results[i] = id
+	}
+	return nil
+}
+
 // Used in Team to represent at-a-glance information about the roster
 type TeamPlayer struct {
-	Name     string `json:"name"`
-	SteamId  string `json:"steamId"`
-	IsLeader bool   `json:"isLeader"`
-	Joined   string `json:"joinedAt"`
+	Name     string  `json:"name"`
+	SteamId  SteamID `json:"steamId"`
+	IsLeader bool    `json:"isLeader"`
+	Joined   Time    `json:"joinedAt"`
 }
 
 // Toplevel endpoint for a team found by id
@@ -97,9 +145,9 @@ type Team struct {
 	SeasonId    int          `json:"seasonId"`
 	DivId       int          `json:"divisionId"`
 	DivName     string       `json:"divisionName"`
-	TeamLeader  string       `json:"teamLeader"`
-	Created     string       `json:"createdAt"`
-	Updated     string       `json:"updatedAt"`
+	TeamLeader  SteamID      `json:"teamLeader"`
+	Created     Time         `json:"createdAt"`
+	Updated     Time         `json:"updatedAt"`
 	Tag         string       `json:"tag"`
 	Name        string       `json:"name"`
 	FinalRank   *int         `json:"finalRank"`
@@ -114,10 +162,10 @@ type PlayerTeamHistory struct {
 	RegionName   string `json:"regionName"`
 	SeasonId     int    `json:"seasonId"`
 	SeasonName   string `json:"seasonName"`
-	Started      string `json:"startedAt"`
+	Started      Time   `json:"startedAt"`
 	DivisionId   int    `json:"divisionId"`
 	DivisionName string `json:"divisionName"`
-	Left         string `json:"leftAt"` //Can be empty to indicate a null value (player hasn't left team)
+	Left         Time   `json:"leftAt"` //Zero (check Left.IsZero()) if the player hasn't left the team
 	TeamName     string `json:"teamName"`
 	TeamTag      string `json:"teamTag"`
 	TeamId       int    `json:"teamId"`
@@ -163,277 +211,417 @@ type Match struct {
 	SeasonName string      `json:"seasonName"`
 	DivName    string      `json:"divName"`
 	SeasonId   int         `json:"seasonId"`
-	MatchDate  string      `json:"matchDate"`
+	MatchDate  Time        `json:"matchDate"`
 	MatchName  string      `json:"matchName"`
+	WinnerId   int         `json:"winner"` //0 if unplayed or tied
 	Teams      []MatchTeam `json:"teams"`
 	Maps       []MatchMap  `json:"maps"`
 }
 
 // An error that comes from POSTing (at least to /search/players. Message has varying fields, but the important ones are constant.
 type PostError struct {
-	StatusCode int    `json:"statusCode"`
-	Error      string `json:"error"`
-	Message    []struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
-	} `json:"message"`
+	StatusCode int                `json:"statusCode"`
+	Error      string             `json:"error"`
+	Message    []PostErrorMessage `json:"message"`
 }
 
-// The RGL type contains all endpoints as methods. Create one with rgl.DefaultRateLimit()
+// A single message in a PostError
+type PostErrorMessage struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// The RGL type contains all endpoints as methods. Create one with rgl.New() or rgl.DefaultRateLimit()
 // or use RGL{} if you don't want to use the ratelimiter (you will have to implement your own, as the rgl api is heavily limited)
 type RGL struct {
-	rl *rate.Limiter
+	rl        *rate.Limiter
+	searchRL  *rate.Limiter   //Used for POSTs instead of rl if set
+	adaptive  *adaptiveLimits //Non-nil if limits should follow RGL's ratelimit headers
+	client    *http.Client
+	baseURL   string
+	userAgent string
+
+	notFoundErrors bool        //Return ErrNotFound instead of zero values, see WithNotFoundError
+	retry          RetryPolicy //Zero value never retries
+	cache          Cache
+	cacheTTLs      CacheTTLs
+	revalidating   *keySet       //URLs being refreshed in the background for a StaleCache
+	inflight       *flightGroup  //Shares identical concurrent requests. Only set by New
+	players        *playerLoader //Batches GetPlayer calls, see WithPlayerBatching
+	resolver       SteamResolver //For vanity URLs
 }
 
 // Create an RGL instance with a default rate limiter based on present ratelimits (2 calls per 1 second)
 func DefaultRateLimit() RGL {
-	r := RGL{}
-	r.rl = rate.NewLimiter(rate.Every(time.Second), 2) //2 requests every second
-	return r
+	return *New()
+}
+
+// The ratelimiter used by New and DefaultRateLimit
+func defaultLimiter() *rate.Limiter {
+	return rate.NewLimiter(rate.Every(time.Second), 2) //2 requests every second
+}
+
+// Full URL of an endpoint path, against the configured base URL
+func (rgl *RGL) endpoint(path string) string {
+	if rgl.baseURL == "" {
+		return RGL_ENDPOINT + path
+	}
+	return rgl.baseURL + path
+}
+
+// Send a request with the configured client and user agent
+func (rgl *RGL) do(req *http.Request) (*http.Response, error) {
+	if rgl.userAgent != "" {
+		req.Header.Set("User-Agent", rgl.userAgent)
+	}
+	client := rgl.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
 }
 
-// Wrapper around time.Parse("2006-01-02T15:04:05.999Z", str)
+// Wrapper around time.Parse("2006-01-02T15:04:05.999Z", str). Time fields are already parsed, this is for raw strings.
 func ToGoTime(str string) time.Time {
 	t, _ := time.Parse("2006-01-02T15:04:05.999Z", str)
 	return t
 }
 
-func (rgl *RGL) get(url string) (io.ReadCloser, error) {
-	if rgl.rl != nil { //If using the pkgs ratelimiter (user should implement their own if they don't want to use the default)
-		ctx := context.Background()
-		err := rgl.rl.Wait(ctx)
-
-		if err != nil {
-			return nil, fmt.Errorf("Error waiting on ratelimiter %v\n", err)
+// GET url, going through the cache (if configured) for this kind of resource
+func (rgl *RGL) get(ctx context.Context, resource Resource, url string) (io.ReadCloser, error) {
+	ttl := rgl.cacheTTL(resource)
+	if ttl > 0 && !bypassCache(ctx) {
+		if cached, ok := rgl.cached(url, ttl); ok {
+			return io.NopCloser(bytes.NewReader(cached)), nil
 		}
 	}
-	resp, err := http.Get(url)
+	raw, err := rgl.fetch(ctx, http.MethodGet, url, nil, true)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting endpoint %s: %v\n", url, err)
-	}
-	if resp.StatusCode == 429 {
-		return nil, fmt.Errorf("Hit ratelimit")
+		return nil, err //An *APIError matches ErrNotFound or ErrRateLimited with errors.Is
 	}
-	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("Not Found")
+	if ttl > 0 {
+		rgl.cache.Set(url, raw, ttl)
 	}
-	return resp.Body, nil //resp.Body is not closed here. Defer it after calling get
+	return io.NopCloser(bytes.NewReader(raw)), nil
 }
 
-func (rgl *RGL) post(url string, body interface{}) (*http.Response, error) {
-	if rgl.rl != nil {
-		ctx := context.Background()
-		err := rgl.rl.Wait(ctx)
-
-		if err != nil {
-			return nil, fmt.Errorf("Error waiting on ratelimiter %v\n", err)
-		}
-	}
+// Like get, but POSTs body as json. Non-2xx responses become an *APIError carrying RGL's PostError messages.
+// POSTs are only retried if readOnly says the endpoint is a lookup that's safe to send twice.
+func (rgl *RGL) post(ctx context.Context, url string, body interface{}, readOnly bool) (io.ReadCloser, error) {
 	reqBody, err := json.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("Error marshaling request body: %v\n", err)
+		return nil, fmt.Errorf("Error marshaling request body: %w", err)
 	}
-	resp, err := http.Post(url, "application/json", bytes.NewReader(reqBody))
+	raw, err := rgl.fetch(ctx, http.MethodPost, url, reqBody, readOnly)
 	if err != nil {
 		return nil, err
 	}
-	//It seems RGL's error API may vary based on the actual endpoint, so I'm going to leave error handling to further down the func chain.
-	return resp, nil
+	return io.NopCloser(bytes.NewReader(raw)), nil
+}
+
+// Read a whole response. Identical requests already in flight are shared rather than sent again.
+// Failures are only retried if idempotent (see send).
+func (rgl *RGL) fetch(ctx context.Context, method string, url string, body []byte, idempotent bool) ([]byte, error) {
+	read := func() ([]byte, error) {
+		resp, err := rgl.send(ctx, method, url, body, idempotent)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		raw, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading response from %s: %w", url, err)
+		}
+		return raw, nil
+	}
+	if rgl.inflight == nil {
+		return read()
+	}
+	key := method + " " + url + "\n" + string(body)
+	for {
+		raw, err, shared := rgl.inflight.do(ctx, key, read)
+		//The shared request ran under another caller's context. If that was cancelled but ours wasn't, try again ourselves.
+		if err != nil && shared && ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			continue
+		}
+		return raw, err
+	}
+}
+
+// Make a request under the ratelimiter, retrying according to the retry policy.
+// Returns the response only if it was 2xx, otherwise an error (an *APIError if RGL responded).
+func (rgl *RGL) send(ctx context.Context, method string, url string, body []byte, idempotent bool) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if err := rgl.wait(ctx, method); err != nil {
+			return nil, err
+		}
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating request for %s: %w", url, err)
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		var wait time.Duration //Delay requested by the server, if any
+		retry := false
+		resp, err := rgl.do(req)
+		if err != nil {
+			err = fmt.Errorf("Error requesting endpoint %s: %w", url, err)
+			retry = idempotent && ctx.Err() == nil
+		} else {
+			rgl.observe(method, resp)
+			if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+				return resp, nil
+			}
+			wait, _ = retryAfter(resp.Header, time.Now())
+			err = newAPIError(resp)
+			retry = idempotent && retryableStatus(resp.StatusCode)
+		}
+
+		if !retry || attempt >= rgl.retry.MaxAttempts {
+			return nil, err
+		}
+		if sleepErr := sleepCtx(ctx, rgl.retry.delay(attempt, wait)); sleepErr != nil {
+			return nil, err
+		}
+	}
 }
 
-// Get player by steam id
-func (rgl *RGL) GetPlayer(steam64 string) (Player, error) {
+// Get player by steam id (any format ParseSteamID accepts, plus vanity URLs if there is a SteamResolver)
+func (rgl *RGL) GetPlayer(id SteamID) (Player, error) {
+	return rgl.GetPlayerCtx(context.Background(), id)
+}
+
+// GetPlayerCtx is like GetPlayer but the request (including any ratelimiter wait) is bound to ctx
+func (rgl *RGL) GetPlayerCtx(ctx context.Context, id SteamID) (Player, error) {
 	var p Player
-	if !strings.HasPrefix(steam64, "765611") {
-		return p, fmt.Errorf("Steam64 must begin with 765611")
+	steam64, err := rgl.ResolveSteamID(ctx, string(id))
+	if err != nil {
+		return p, err
+	}
+	url := rgl.endpoint(playerPath + string(steam64))
+	var body io.ReadCloser
+	if rgl.players != nil {
+		body, err = rgl.players.get(ctx, url, steam64)
+	} else {
+		body, err = rgl.get(ctx, ResourcePlayer, url)
 	}
-	url := PLAYER_ENDPOINT + steam64
-	body, err := rgl.get(url)
 	if err != nil {
-		if err.Error() == "Not Found" {
+		if errors.Is(err, ErrNotFound) && !rgl.notFoundErrors {
 			return p, nil
 		}
-		return p, fmt.Errorf("Error getting player: %v", err)
+		return p, fmt.Errorf("Error getting player: %w", err)
 	}
 	defer body.Close()
 	err = json.NewDecoder(body).Decode(&p)
 	if err != nil {
-		return p, fmt.Errorf("Error decoding json response: %v", err)
+		return p, fmt.Errorf("Error decoding json response: %w", err)
 	}
 	return p, nil
 }
 
 // Get team by RGL Id
 func (rgl *RGL) GetTeam(id int) (Team, error) {
+	return rgl.GetTeamCtx(context.Background(), id)
+}
+
+// GetTeamCtx is like GetTeam but the request (including any ratelimiter wait) is bound to ctx
+func (rgl *RGL) GetTeamCtx(ctx context.Context, id int) (Team, error) {
 	var t Team
-	url := TEAM_ENDPOINT + fmt.Sprint(id)
-	body, err := rgl.get(url)
+	url := rgl.endpoint(teamPath + fmt.Sprint(id))
+	body, err := rgl.get(ctx, ResourceTeam, url)
 	if err != nil {
-		if err.Error() == "Not Found" {
+		if errors.Is(err, ErrNotFound) && !rgl.notFoundErrors {
 			return t, nil
 		}
-		return t, fmt.Errorf("Error getting team: %v", err)
+		return t, fmt.Errorf("Error getting team: %w", err)
 	}
 	defer body.Close()
 	err = json.NewDecoder(body).Decode(&t)
 	if err != nil {
-		return t, fmt.Errorf("Error decoding json response: %v", err)
+		return t, fmt.Errorf("Error decoding json response: %w", err)
 	}
 	return t, nil
 }
 
 // Get season by RGL Id
 func (rgl *RGL) GetSeason(id int) (Season, error) {
+	return rgl.GetSeasonCtx(context.Background(), id)
+}
+
+// GetSeasonCtx is like GetSeason but the request (including any ratelimiter wait) is bound to ctx
+func (rgl *RGL) GetSeasonCtx(ctx context.Context, id int) (Season, error) {
 	var s Season
-	url := SEASON_ENDPOINT + fmt.Sprint(id)
-	body, err := rgl.get(url)
+	url := rgl.endpoint(seasonPath + fmt.Sprint(id))
+	body, err := rgl.get(ctx, ResourceSeason, url)
 	if err != nil {
-		if err.Error() == "Not Found" {
+		if errors.Is(err, ErrNotFound) && !rgl.notFoundErrors {
 			return s, nil
 		}
-		return s, fmt.Errorf("Error getting season: %v", err)
+		return s, fmt.Errorf("Error getting season: %w", err)
 	}
 	defer body.Close()
 	err = json.NewDecoder(body).Decode(&s)
 	if err != nil {
-		return s, fmt.Errorf("Error decoding json response: %v", err)
+		return s, fmt.Errorf("Error decoding json response: %w", err)
 	}
 	return s, nil
 }
 
 // Search for players whose aliases contain the string. Take the first `take` results, skipping the first `skip`.
-func (rgl *RGL) SearchPlayers(alias string, take int, skip int) (SearchResults, error) {
-	var results SearchResults
+func (rgl *RGL) SearchPlayers(alias string, take int, skip int) (PlayerSearchResults, error) {
+	return rgl.SearchPlayersCtx(context.Background(), alias, take, skip)
+}
+
+// SearchPlayersCtx is like SearchPlayers but the request (including any ratelimiter wait) is bound to ctx
+func (rgl *RGL) SearchPlayersCtx(ctx context.Context, alias string, take int, skip int) (PlayerSearchResults, error) {
+	var results PlayerSearchResults
 	if len(alias) < 2 {
 		return results, fmt.Errorf("Length of alias must be at least 2")
 	}
-	url := fmt.Sprintf("%s?take=%d&skip=%d", SEARCH_ALIAS_ENDPOINT, take, skip)
-	resp, err := rgl.post(url, struct {
+	url := fmt.Sprintf("%s?take=%d&skip=%d", rgl.endpoint(searchAliasPath), take, skip)
+	body, err := rgl.post(ctx, url, struct {
 		NameContains string `json:"nameContains"`
-	}{alias})
+	}{alias}, true) //A search, safe to retry
 	if err != nil {
-		return results, fmt.Errorf("Error POSTing for player aliases: %v", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		var pe PostError
-		err = json.NewDecoder(resp.Body).Decode(&pe)
-		if err != nil {
-			if err.Error() == "Not Found" {
-				return results, nil
-			}
-			return results, err
+		if errors.Is(err, ErrNotFound) {
+			return results, nil
 		}
-		erCode := pe.Message[0].Code
-		if erCode == "invalid_type" { //Neither of these should occur if the library operates properly
-			return results, fmt.Errorf("Library error (invalid_type)") //nameContains encoded incorrectly
-		} else if erCode == "too_small" {
-			return results, fmt.Errorf("Alias too short") //len(alias) < 2 check should make this redundant
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			if apiErr.Code() == "invalid_type" { //Neither of these should occur if the library operates properly
+				return results, fmt.Errorf("Library error (invalid_type): %w", err) //nameContains encoded incorrectly
+			} else if apiErr.Code() == "too_small" {
+				return results, fmt.Errorf("Alias too short: %w", err) //len(alias) < 2 check should make this redundant
+			}
 		}
-		return results, fmt.Errorf("PostError body: %v", pe)
+		return results, fmt.Errorf("Error POSTing for player aliases: %w", err)
 	}
-	err = json.NewDecoder(resp.Body).Decode(&results)
+	defer body.Close()
+	err = json.NewDecoder(body).Decode(&results)
 	if err != nil {
-		return results, fmt.Errorf("Error decoding json response: %v", err)
+		return results, fmt.Errorf("Error decoding json response: %w", err)
 	}
 	return results, nil
 }
 
-// Search multiple IDs for RGL players
-func (rgl *RGL) BulkPlayers(ids []string) ([]Player, error) {
+// Search multiple IDs (any format ParseSteamID accepts) for RGL players. Large inputs are split into several requests.
+// Invalid IDs and IDs without an RGL profile are left out, use BulkPlayersDetailed to find out which ones those were.
+func (rgl *RGL) BulkPlayers(ids []SteamID) ([]Player, error) {
+	return rgl.BulkPlayersCtx(context.Background(), ids)
+}
+
+// BulkPlayersCtx is like BulkPlayers but the request (including any ratelimiter wait) is bound to ctx
+func (rgl *RGL) BulkPlayersCtx(ctx context.Context, ids []SteamID) ([]Player, error) {
+	result, err := rgl.BulkPlayersDetailed(ctx, ids)
+	return result.Players, err
+}
+
+// A single getmany request, with no more than BULK_PLAYER_LIMIT ids
+func (rgl *RGL) bulkPlayers(ctx context.Context, ids []SteamID) ([]Player, error) {
 	players := make([]Player, 0)
-	url := BULK_PLAYER_ENDPOINT
-	resp, err := rgl.post(url, ids)
+	url := rgl.endpoint(bulkPlayerPath)
+	body, err := rgl.post(ctx, url, ids, true) //A lookup, safe to retry
 	if err != nil {
-		return players, fmt.Errorf("Error POSTing for bulk players: %v", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode == 200 {
-		err = json.NewDecoder(resp.Body).Decode(&players)
-		return players, err //players will be the empty slice declared at the top if err is not nil
-	} else if resp.StatusCode == 404 {
-		return players, nil
-	} else { //statuscode is technically 400 but returns a json PostError.StatusCode = 404
-		var pe PostError
-		err = json.NewDecoder(resp.Body).Decode(&pe)
-		if err != nil {
-			return players, err
+		if errors.Is(err, ErrNotFound) { //statuscode is technically 400 but returns a json PostError.StatusCode = 404
+			return players, nil
 		}
-		erCode := pe.Message[0].Code
-		if erCode == "invalid_string" {
-			return players, fmt.Errorf("One or more steamids was invalid")
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code() == "invalid_string" {
+			return players, fmt.Errorf("One or more steamids was invalid: %w", err)
 		}
-		return nil, fmt.Errorf("PostError body: %v", pe)
+		return players, fmt.Errorf("Error POSTing for bulk players: %w", err)
 	}
+	defer body.Close()
+	err = json.NewDecoder(body).Decode(&players)
+	return players, err //players will be the empty slice declared at the top if err is not nil
 }
 
 // Get match by RGL Id
 func (rgl *RGL) GetMatch(id int) (Match, error) {
+	return rgl.GetMatchCtx(context.Background(), id)
+}
+
+// GetMatchCtx is like GetMatch but the request (including any ratelimiter wait) is bound to ctx
+func (rgl *RGL) GetMatchCtx(ctx context.Context, id int) (Match, error) {
 	var m Match
-	url := MATCH_ENDPOINT + fmt.Sprint(id)
-	body, err := rgl.get(url)
+	url := rgl.endpoint(matchPath + fmt.Sprint(id))
+	body, err := rgl.get(ctx, ResourceMatch, url)
 	if err != nil {
-		if err.Error() == "Not Found" {
+		if errors.Is(err, ErrNotFound) && !rgl.notFoundErrors {
 			return m, nil
 		}
-		return m, fmt.Errorf("Error getting match: %v", err)
+		return m, fmt.Errorf("Error getting match: %w", err)
 	}
 	defer body.Close()
 	err = json.NewDecoder(body).Decode(&m)
 	if err != nil {
-		return m, fmt.Errorf("Error decoding json response: %v", err)
+		return m, fmt.Errorf("Error decoding json response: %w", err)
 	}
 	return m, nil
 }
 
 // Bulk search for teams whose names or tags contain the partial string.
-func (rgl *RGL) SearchTeams(partial string, take int, skip int) (SearchResults, error) {
-	var results SearchResults
+func (rgl *RGL) SearchTeams(partial string, take int, skip int) (TeamSearchResults, error) {
+	return rgl.SearchTeamsCtx(context.Background(), partial, take, skip)
+}
+
+// SearchTeamsCtx is like SearchTeams but the request (including any ratelimiter wait) is bound to ctx
+func (rgl *RGL) SearchTeamsCtx(ctx context.Context, partial string, take int, skip int) (TeamSearchResults, error) {
+	var results TeamSearchResults
 	if len(partial) < 2 {
 		return results, fmt.Errorf("Length of partial string must be at least 2")
 	}
-	url := fmt.Sprintf("%s?take=%d&skip=%d", SEARCH_TEAM_ENDPOINT, take, skip)
-	resp, err := rgl.post(url, struct {
+	url := fmt.Sprintf("%s?take=%d&skip=%d", rgl.endpoint(searchTeamPath), take, skip)
+	body, err := rgl.post(ctx, url, struct {
 		NameContains string `json:"nameContains"`
-	}{partial})
+	}{partial}, true) //A search, safe to retry
 	if err != nil {
-		return results, fmt.Errorf("Error POSTing for team bulk: %v", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		var pe PostError
-		err = json.NewDecoder(resp.Body).Decode(&pe)
-		if err != nil {
-			if err.Error() == "Not Found" {
-				return results, nil
-			}
-			return results, err
+		if errors.Is(err, ErrNotFound) {
+			return results, nil
 		}
-		erCode := pe.Message[0].Code
-		if erCode == "invalid_type" { //Neither of these should occur if the library operates properly
-			return results, fmt.Errorf("Library error (invalid_type)") //nameContains encoded incorrectly
-		} else if erCode == "too_small" {
-			return results, fmt.Errorf("Alias too short") //len(alias) < 2 check should make this redundant
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			if apiErr.Code() == "invalid_type" { //Neither of these should occur if the library operates properly
+				return results, fmt.Errorf("Library error (invalid_type): %w", err) //nameContains encoded incorrectly
+			} else if apiErr.Code() == "too_small" {
+				return results, fmt.Errorf("Alias too short: %w", err) //len(alias) < 2 check should make this redundant
+			}
 		}
-		return results, fmt.Errorf("PostError body: %v", pe)
+		return results, fmt.Errorf("Error POSTing for team bulk: %w", err)
 	}
-	err = json.NewDecoder(resp.Body).Decode(&results)
+	defer body.Close()
+	err = json.NewDecoder(body).Decode(&results)
 	if err != nil {
-		return results, fmt.Errorf("Error decoding json response: %v", err)
+		return results, fmt.Errorf("Error decoding json response: %w", err)
 	}
 	return results, nil
 }
 
-// Get a player's teams (past and present). Current teams have the Left field as ""
-func (rgl *RGL) GetPlayerTeamHistory(id string) ([]PlayerTeamHistory, error) {
+// Get a player's teams (past and present) by steam id (any format ParseSteamID accepts). Current teams have a zero Left field
+func (rgl *RGL) GetPlayerTeamHistory(id SteamID) ([]PlayerTeamHistory, error) {
+	return rgl.GetPlayerTeamHistoryCtx(context.Background(), id)
+}
+
+// GetPlayerTeamHistoryCtx is like GetPlayerTeamHistory but the request (including any ratelimiter wait) is bound to ctx
+func (rgl *RGL) GetPlayerTeamHistoryCtx(ctx context.Context, id SteamID) ([]PlayerTeamHistory, error) {
 	teams := make([]PlayerTeamHistory, 0)
-	url := PLAYER_ENDPOINT + id + "/teams"
-	body, err := rgl.get(url)
+	steam64, err := rgl.ResolveSteamID(ctx, string(id))
 	if err != nil {
-		if err.Error() == "Not Found" {
+		return teams, err
+	}
+	url := rgl.endpoint(playerPath + string(steam64) + "/teams")
+	body, err := rgl.get(ctx, ResourceTeamHistory, url)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) && !rgl.notFoundErrors {
 			return teams, nil
 		}
-		return teams, fmt.Errorf("Error getting team history")
+		return teams, fmt.Errorf("Error getting team history: %w", err)
 	}
 	defer body.Close()
 	err = json.NewDecoder(body).Decode(&teams)
@@ -445,11 +633,16 @@ func (rgl *RGL) GetPlayerTeamHistory(id string) ([]PlayerTeamHistory, error) {
 
 // A paginated look at RGL bans. (Newest first). This is a historic record and includes expired bans, as far as I can tell.
 func (rgl *RGL) GetBans(take int, skip int) ([]BulkBan, error) {
+	return rgl.GetBansCtx(context.Background(), take, skip)
+}
+
+// GetBansCtx is like GetBans but the request (including any ratelimiter wait) is bound to ctx
+func (rgl *RGL) GetBansCtx(ctx context.Context, take int, skip int) ([]BulkBan, error) {
 	bans := make([]BulkBan, 0)
-	url := fmt.Sprintf("https://api.rgl.gg/v0/bans/paged?take=%d&skip=%d", take, skip)
-	body, err := rgl.get(url)
+	url := fmt.Sprintf("%s?take=%d&skip=%d", rgl.endpoint(bansPath), take, skip)
+	body, err := rgl.get(ctx, ResourceBans, url)
 	if err != nil {
-		return bans, fmt.Errorf("Error getting paginated bans")
+		return bans, fmt.Errorf("Error getting paginated bans: %w", err)
 	}
 	defer body.Close()
 	err = json.NewDecoder(body).Decode(&bans)
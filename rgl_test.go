@@ -1,8 +1,10 @@
 package rgl
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 	"testing"
 	"time"
 )
@@ -15,7 +17,7 @@ func TestGetPlayer(t *testing.T) {
 		SteamId: "76561198098770013",
 		Avatar:  "https://steamcdn-a.akamaihd.net/steamcommunity/public/images/avatars/81/8148c37b434814fb7a4bc175a608c1353b4d0a11_full.jpg",
 		Name:    "Captain Zidgel",
-		Updated: "2023-02-12T21:48:27.196Z",
+		Updated: Time{time.Date(2023, 2, 12, 21, 48, 27, 196000000, time.UTC)},
 		Status: PlayerStatus{
 			IsVerified:    false,
 			IsBanned:      false,
@@ -35,7 +37,7 @@ func TestGetPlayer(t *testing.T) {
 
 	//Second test: Just verify some other fields not on the other player ID (I didn't want to rewrite the struct literal)
 	expected_ban := &Ban{
-		Ends:   "9999-08-24T06:20:00.000Z",
+		Ends:   Time{time.Date(9999, 8, 24, 6, 20, 0, 0, time.UTC)},
 		Reason: "Old account, new account: <a href=\"https://rgl.gg/Public/PlayerProfile.aspx?p=76561198113990147\">https://rgl.gg/Public/PlayerProfile.aspx?p=76561198113990147</a>\r\n</br></br>\r\n(10/9/2021) - Failure to Submit Demos: 1st Offense",
 	}
 
@@ -44,16 +46,23 @@ func TestGetPlayer(t *testing.T) {
 	require.Equal(t, expected_ban, got.Ban, "Should have equal bans")
 
 	//before, _, _ := strings.Cut(got.Ban.Ends, "T")
-	tim := ToGoTime(got.Ban.Ends)
+	tim := got.Ban.Ends.Time
 	expect_time := time.Date(9999, 8, 24, 6, 20, 0, 0, time.UTC)
 	require.Equal(t, expect_time, tim, "Should have equal ban times")
 
 	_, err = r.GetPlayer("unvalidated12345")
 	require.Error(t, err)
 
-	got, err = r.GetPlayer("7656111111111111111111111111")
+	_, err = r.GetPlayer("7656111111111111111111111111")
+	require.ErrorIs(t, err, ErrInvalidSteamID, "Malformed IDs should be rejected before requesting")
+
+	got, err = r.GetPlayer("76561199999999998")
 	require.NoError(t, err)
 	require.Equal(t, Player{}, got, "Should get empty object for 404")
+
+	got, err = r.GetPlayer("[U:1:138504285]")
+	require.NoError(t, err)
+	require.Equal(t, expected.Name, got.Name, "Should accept other steam id formats")
 }
 
 func TestGetTeam(t *testing.T) {
@@ -598,7 +607,7 @@ func TestSearchPlayers(t *testing.T) {
   "count": 1,
   "totalHitCount": 1
 }`
-	var expected SearchResults
+	var expected PlayerSearchResults
 	json.Unmarshal([]byte(str), &expected)
 	got, err := r.SearchPlayers("Zidgel", 100, 0)
 	require.NoError(t, err)
@@ -606,8 +615,8 @@ func TestSearchPlayers(t *testing.T) {
 
 	got, err = r.SearchPlayers("No one has this alias!", 1, 0)
 	require.NoError(t, err)
-	require.Equal(t, len(SearchResults{}.Results), 0, "Should get empty results for 404")
-	require.Equal(t, SearchResults{Results: make([]string, 0)}, got, "Should get empty object for no matches")
+	require.Equal(t, len(PlayerSearchResults{}.Results), 0, "Should get empty results for 404")
+	require.Equal(t, PlayerSearchResults{Results: make([]SteamID, 0)}, got, "Should get empty object for no matches")
 }
 
 func TestBulkPlayers(t *testing.T) {
@@ -655,15 +664,15 @@ func TestBulkPlayers(t *testing.T) {
     }
   }
 ]`
-	p, err := r.BulkPlayers([]string{"76561198292350104"})
+	p, err := r.BulkPlayers([]SteamID{"76561198292350104"})
 	require.NoError(t, err)
 	require.Len(t, p, 0, "Should get no results for non-rgl player")
 
-	p, err = r.BulkPlayers([]string{"765611980987700133"})
+	p, err = r.BulkPlayers([]SteamID{"765611980987700133"})
 	require.NoError(t, err)
 	require.Len(t, p, 0, "Should get no results for invalid ID")
 
-	p, err = r.BulkPlayers([]string{"76561198098770013", "76561197970669109", "765611980987700133"}) //Last ID is invalid
+	p, err = r.BulkPlayers([]SteamID{"76561198098770013", "76561197970669109", "765611980987700133"}) //Last ID is invalid
 	require.NoError(t, err)
 	var expected []Player
 	json.Unmarshal([]byte(str), &expected)
@@ -748,8 +757,10 @@ func TestSearchTeam(t *testing.T) {
   "count": 25,
   "totalHitCount": 27
 }`
-	var expected SearchResults
+	var expected TeamSearchResults
 	json.Unmarshal([]byte(str), &expected)
+	require.Equal(t, 25, expected.Count)
+	require.Equal(t, 42, expected.Results[0], "Team IDs should decode to ints")
 
 	results, err := r.SearchTeams("froyo", 25, 0)
 	require.NoError(t, err)
@@ -757,7 +768,7 @@ func TestSearchTeam(t *testing.T) {
 
 	results, err = r.SearchTeams("No one has this team name!", 1, 1)
 	require.NoError(t, err)
-	require.Equal(t, SearchResults{Results: make([]string, 0)}, results, "Should get empty results for 404")
+	require.Equal(t, TeamSearchResults{Results: make([]int, 0)}, results, "Should get empty results for 404")
 }
 
 func TestGetPlayerTeamHistory(t *testing.T) {
@@ -794,7 +805,7 @@ func TestGetPlayerTeamHistory(t *testing.T) {
 	require.Equal(t, expected, results, "Should get results for valid request")
 
 	results, err = r.GetPlayerTeamHistory("765611980987700133")
-	require.NoError(t, err)
+	require.ErrorIs(t, err, ErrInvalidSteamID)
 	require.Equal(t, make([]PlayerTeamHistory, 0), results, "Should get empty slice for invalid request")
 }
 
@@ -810,3 +821,16 @@ func TestGetBans(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, len(results) == 0)
 }
+
+func TestContextCancelsRatelimitWait(t *testing.T) {
+	// A limiter with a single token that refills once an hour: the second call has to wait on it
+	limited := RGL{rl: rate.NewLimiter(rate.Every(time.Hour), 1)}
+	limited.rl.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := limited.GetTeamCtx(ctx, 5979)
+	require.Error(t, err, "Should get error when context expires before a token is available")
+	require.Less(t, time.Since(start), time.Second, "Should not block on the ratelimiter past the deadline")
+}
@@ -0,0 +1,184 @@
+package rgl
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default RosterWatcher.Interval
+const ROSTER_WATCH_INTERVAL = 10 * time.Minute
+
+// A change to a team, found by DiffTeams or a RosterWatcher. Use a type switch to tell them apart:
+//
+//	switch e := event.(type) {
+//	case rgl.PlayerJoined:
+//		fmt.Println(e.Player.Name, "joined", e.Team.Name)
+//	}
+type RosterEvent interface {
+	TeamId() int
+}
+
+// A player was added to the roster
+type PlayerJoined struct {
+	Team   Team // After the change, as are the Teams in the other events
+	Player TeamPlayer
+}
+
+// A player left or was removed from the roster
+type PlayerLeft struct {
+	Team   Team
+	Player TeamPlayer // As they were on the old roster
+}
+
+// The team leader changed
+type LeaderChanged struct {
+	Team      Team
+	OldLeader SteamID
+	NewLeader SteamID
+}
+
+// The team's name or tag changed
+type Renamed struct {
+	Team    Team
+	OldName string
+	OldTag  string
+}
+
+// The team was moved to another division
+type DivisionChanged struct {
+	Team       Team
+	OldDivId   int
+	OldDivName string
+}
+
+func (e PlayerJoined) TeamId() int    { return e.Team.Id }
+func (e PlayerLeft) TeamId() int      { return e.Team.Id }
+func (e LeaderChanged) TeamId() int   { return e.Team.Id }
+func (e Renamed) TeamId() int         { return e.Team.Id }
+func (e DivisionChanged) TeamId() int { return e.Team.Id }
+
+// The changes between two fetches of the same team: Renamed, DivisionChanged, then PlayerLeft and PlayerJoined (in roster order), then LeaderChanged.
+func DiffTeams(before Team, after Team) []RosterEvent {
+	events := make([]RosterEvent, 0)
+	if before.Name != after.Name || before.Tag != after.Tag {
+		events = append(events, Renamed{Team: after, OldName: before.Name, OldTag: before.Tag})
+	}
+	if before.DivId != after.DivId {
+		events = append(events, DivisionChanged{Team: after, OldDivId: before.DivId, OldDivName: before.DivName})
+	}
+	oldRoster := make(map[SteamID]bool, len(before.Players))
+	for _, p := range before.Players {
+		oldRoster[p.SteamId] = true
+	}
+	newRoster := make(map[SteamID]bool, len(after.Players))
+	for _, p := range after.Players {
+		newRoster[p.SteamId] = true
+	}
+	for _, p := range before.Players {
+		if !newRoster[p.SteamId] {
+			events = append(events, PlayerLeft{Team: after, Player: p})
+		}
+	}
+	for _, p := range after.Players {
+		if !oldRoster[p.SteamId] {
+			events = append(events, PlayerJoined{Team: after, Player: p})
+		}
+	}
+	if before.TeamLeader != after.TeamLeader {
+		events = append(events, LeaderChanged{Team: after, OldLeader: before.TeamLeader, NewLeader: after.TeamLeader})
+	}
+	return events
+}
+
+// Polls a set of teams and reports changes to them as RosterEvents.
+// The first poll of each team only records it, so events are changes since the watcher started.
+type RosterWatcher struct {
+	Interval time.Duration   // Time between polls
+	OnError  func(err error) // Called when a poll fails in Run or Watch. Nothing is recorded, so the next poll catches up.
+
+	rgl   *RGL
+	mu    sync.Mutex
+	ids   map[int]bool
+	teams map[int]Team //Last seen state of each team
+}
+
+// Create a RosterWatcher for teamIds with ROSTER_WATCH_INTERVAL
+func (rgl *RGL) NewRosterWatcher(teamIds ...int) *RosterWatcher {
+	w := &RosterWatcher{Interval: ROSTER_WATCH_INTERVAL, rgl: rgl, ids: make(map[int]bool), teams: make(map[int]Team)}
+	w.Add(teamIds...)
+	return w
+}
+
+// Start watching more teams
+func (w *RosterWatcher) Add(teamIds ...int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range teamIds {
+		w.ids[id] = true
+	}
+}
+
+// Stop watching teams
+func (w *RosterWatcher) Remove(teamIds ...int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range teamIds {
+		delete(w.ids, id)
+		delete(w.teams, id)
+	}
+}
+
+// Fetch every watched team (RESOLVE_CONCURRENCY at a time, bypassing the cache) and return what changed since the last poll, by team ID.
+// Teams that 404 are skipped until they come back.
+func (w *RosterWatcher) Poll(ctx context.Context) ([]RosterEvent, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ids := make([]int, 0, len(w.ids))
+	for id := range w.ids {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	fetched := make([]Team, len(ids))
+	err := forEachLimit(BypassCache(ctx), len(ids), RESOLVE_CONCURRENCY, func(ctx context.Context, i int) error {
+		var err error
+		fetched[i], err = w.rgl.GetTeamCtx(ctx, ids[i])
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]RosterEvent, 0)
+	for _, team := range fetched {
+		if team.Id == 0 {
+			continue
+		}
+		if old, ok := w.teams[team.Id]; ok {
+			events = append(events, DiffTeams(old, team)...)
+		}
+		w.teams[team.Id] = team
+	}
+	return events, nil
+}
+
+// Poll every Interval (starting now) and call fn for each event, until ctx is done.
+// An Interval of 0 or less means ROSTER_WATCH_INTERVAL. Failed polls go to OnError. Always returns ctx's error.
+func (w *RosterWatcher) Run(ctx context.Context, fn func(RosterEvent)) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = ROSTER_WATCH_INTERVAL
+	}
+	return pollEvery(ctx, interval, w.Poll, w.OnError, fn)
+}
+
+// Like Run, but delivers events on the returned channel, which is closed once ctx is done
+func (w *RosterWatcher) Watch(ctx context.Context) <-chan RosterEvent {
+	return watchChan(ctx, w.Run)
+}
@@ -0,0 +1,91 @@
+package rgl
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDiffTeams(t *testing.T) {
+	before := Team{
+		Id: 5979, Name: "nut.city", Tag: "nut.", DivId: 12, DivName: "Intermediate", TeamLeader: "76561198098770013",
+		Players: []TeamPlayer{{SteamId: "76561198098770013", Name: "Zidgel"}, {SteamId: "76561197970669109", Name: "b4nny"}},
+	}
+	require.Empty(t, DiffTeams(before, before))
+
+	after := before
+	after.Name = "nut.club"
+	after.DivId, after.DivName = 13, "Main"
+	after.TeamLeader = "76561198011940487"
+	after.Players = []TeamPlayer{{SteamId: "76561198098770013", Name: "Zidgel"}, {SteamId: "76561198011940487", Name: "sunny"}}
+	require.Equal(t, []RosterEvent{
+		Renamed{Team: after, OldName: "nut.city", OldTag: "nut."},
+		DivisionChanged{Team: after, OldDivId: 12, OldDivName: "Intermediate"},
+		PlayerLeft{Team: after, Player: before.Players[1]},
+		PlayerJoined{Team: after, Player: after.Players[1]},
+		LeaderChanged{Team: after, OldLeader: "76561198098770013", NewLeader: "76561198011940487"},
+	}, DiffTeams(before, after))
+}
+
+func TestRosterWatcher(t *testing.T) {
+	var mu sync.Mutex
+	teams := map[string]Team{
+		"5979": {Id: 5979, Name: "nut.city", Players: []TeamPlayer{{SteamId: "76561198098770013"}}},
+		"5819": {Id: 5819, Name: "Sunny"},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/teams/", func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		team, ok := teams[req.URL.Path[len("/v0/teams/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(team)
+	})
+	tr := newTestRGL(t, mux, WithCache(NewLRUCache(10), nil))
+	ctx := context.Background()
+
+	w := tr.NewRosterWatcher(5979, 5819, 404)
+	events, err := w.Poll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, events, "The first poll should only record the teams")
+
+	mu.Lock()
+	sunny := teams["5819"]
+	sunny.Players = []TeamPlayer{{SteamId: "76561198011940487"}}
+	teams["5819"] = sunny
+	mu.Unlock()
+	events, err = w.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 1, "Changes should be seen past the cache")
+	joined, ok := events[0].(PlayerJoined)
+	require.True(t, ok)
+	require.Equal(t, 5819, joined.TeamId())
+	require.Equal(t, SteamID("76561198011940487"), joined.Player.SteamId)
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w.Interval = 10 * time.Millisecond
+	ch := w.Watch(watchCtx)
+	mu.Lock()
+	nut := teams["5979"]
+	nut.Name = "nut.club"
+	teams["5979"] = nut
+	mu.Unlock()
+	event := <-ch
+	require.Equal(t, Renamed{Team: nut, OldName: "nut.city"}, event)
+	cancel()
+	for range ch {
+	}
+
+	w.Interval = 0
+	require.NotPanics(t, func() {
+		require.ErrorIs(t, w.Run(watchCtx, func(RosterEvent) {}), context.Canceled)
+	}, "An Interval of 0 should fall back to the default")
+}
@@ -0,0 +1,162 @@
+package rgl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// One team's row in a division table
+type Standing struct {
+	Team          Team
+	Played        int
+	Wins          int
+	Losses        int
+	Ties          int
+	MapWins       int
+	MapLosses     int
+	Points        float64
+	ForfeitWins   int // Wins by the other team forfeiting, included in Wins
+	ForfeitLosses int // Matches this team forfeited, included in Losses
+}
+
+// Maps won minus maps lost
+func (s Standing) MapDiff() int {
+	return s.MapWins - s.MapLosses
+}
+
+// A division's league table, best team first
+type DivisionStandings struct {
+	DivId   int
+	DivName string
+	Rows    []Standing
+}
+
+// Fetch every team and match in a season and compute its standings (see ComputeStandings).
+// Teams and matches are fetched RESOLVE_CONCURRENCY at a time.
+func (rgl *RGL) Standings(ctx context.Context, seasonID int) ([]DivisionStandings, error) {
+	season, err := rgl.GetSeasonCtx(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := rgl.getTeams(ctx, season.Teams)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting season teams: %w", err)
+	}
+	matches, err := rgl.getMatches(ctx, season.Matches)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting season matches: %w", err)
+	}
+	return ComputeStandings(teams, matches), nil
+}
+
+// Build division tables from a season's teams and matches, ordered by DivId.
+// Rows are ordered by points, then wins, then head-to-head points between the tied teams, then map difference, then name.
+// Unplayed matches and matches against teams not in teams are ignored. Maps aren't counted for matches where the sides can't be told (see Match.Home).
+func ComputeStandings(teams []Team, matches []Match) []DivisionStandings {
+	rows := make(map[int]*Standing, len(teams))
+	for _, t := range teams {
+		rows[t.Id] = &Standing{Team: t}
+	}
+	// Points each team took off each opponent, for head-to-head
+	h2h := make(map[[2]int]float64)
+
+	for _, m := range matches {
+		if len(m.Teams) != 2 || !m.IsPlayed() {
+			continue
+		}
+		a, b := m.Teams[0], m.Teams[1]
+		aRow, bRow := rows[a.Id], rows[b.Id]
+		if aRow == nil || bRow == nil {
+			continue
+		}
+		aPoints, _ := a.PointsValue()
+		bPoints, _ := b.PointsValue()
+		aMaps, bMaps, _ := m.TeamMapWins(a.Id) //Not counted if the sides can't be told
+		aRow.add(aPoints, aMaps, bMaps)
+		bRow.add(bPoints, bMaps, aMaps)
+		h2h[[2]int{a.Id, b.Id}] += aPoints
+		h2h[[2]int{b.Id, a.Id}] += bPoints
+
+		winner, ok := m.Winner()
+		if !ok {
+			aRow.Ties++
+			bRow.Ties++
+			continue
+		}
+		winRow, loseRow := aRow, bRow
+		if winner.Id == b.Id {
+			winRow, loseRow = bRow, aRow
+		}
+		winRow.Wins++
+		loseRow.Losses++
+		if m.IsForfeit() {
+			winRow.ForfeitWins++
+			loseRow.ForfeitLosses++
+		}
+	}
+
+	divs := make(map[int]*DivisionStandings)
+	for _, t := range teams {
+		div, ok := divs[t.DivId]
+		if !ok {
+			div = &DivisionStandings{DivId: t.DivId, DivName: t.DivName}
+			divs[t.DivId] = div
+		}
+		div.Rows = append(div.Rows, *rows[t.Id])
+	}
+	standings := make([]DivisionStandings, 0, len(divs))
+	for _, div := range divs {
+		sortStandings(div.Rows, h2h)
+		standings = append(standings, *div)
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		return standings[i].DivId < standings[j].DivId
+	})
+	return standings
+}
+
+func (s *Standing) add(points float64, mapWins int, mapLosses int) {
+	s.Played++
+	s.Points += points
+	s.MapWins += mapWins
+	s.MapLosses += mapLosses
+}
+
+// Sort rows best first. Teams level on points and wins are split by a mini table of only their matches against each other,
+// so three way ties are handled the same as two way ones.
+func sortStandings(rows []Standing, h2h map[[2]int]float64) {
+	level := func(a, b Standing) bool {
+		return a.Points == b.Points && a.Wins == b.Wins
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Points != rows[j].Points {
+			return rows[i].Points > rows[j].Points
+		}
+		return rows[i].Wins > rows[j].Wins
+	})
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && level(rows[start], rows[end]) {
+			end++
+		}
+		tied := rows[start:end]
+		mini := make(map[int]float64, len(tied))
+		for _, a := range tied {
+			for _, b := range tied {
+				mini[a.Team.Id] += h2h[[2]int{a.Team.Id, b.Team.Id}]
+			}
+		}
+		sort.SliceStable(tied, func(i, j int) bool {
+			a, b := tied[i], tied[j]
+			if mini[a.Team.Id] != mini[b.Team.Id] {
+				return mini[a.Team.Id] > mini[b.Team.Id]
+			}
+			if a.MapDiff() != b.MapDiff() {
+				return a.MapDiff() > b.MapDiff()
+			}
+			return a.Team.Name < b.Team.Name
+		})
+		start = end
+	}
+}
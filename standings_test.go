@@ -0,0 +1,108 @@
+package rgl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func standingsMatch(id int, home int, homePoints string, away int, awayPoints string, maps ...MatchMap) Match {
+	return Match{
+		Id:    id,
+		Teams: []MatchTeam{{Id: home, IsHome: true, Points: homePoints}, {Id: away, Points: awayPoints}},
+		Maps:  maps,
+	}
+}
+
+func TestComputeStandings(t *testing.T) {
+	teams := []Team{
+		{Id: 1, Name: "Alpha", DivId: 2, DivName: "Main"},
+		{Id: 2, Name: "Bravo", DivId: 2, DivName: "Main"},
+		{Id: 3, Name: "Charlie", DivId: 2, DivName: "Main"},
+		{Id: 4, Name: "Delta", DivId: 1, DivName: "Invite"},
+	}
+	matches := []Match{
+		standingsMatch(10, 1, "3", 2, "0", MatchMap{HomeScore: 5, AwayScore: 0}),
+		standingsMatch(11, 2, "3", 3, "0", MatchMap{HomeScore: 5, AwayScore: 1}),
+		standingsMatch(12, 3, "3", 1, "0", MatchMap{}), //Forfeit
+		standingsMatch(13, 1, "", 3, ""),               //Unplayed
+		standingsMatch(14, 4, "3", 99, "0", MatchMap{HomeScore: 5}),
+	}
+	standings := ComputeStandings(teams, matches)
+	require.Len(t, standings, 2)
+	require.Equal(t, "Invite", standings[0].DivName, "Divisions should be ordered by id")
+	require.Len(t, standings[0].Rows, 1)
+	require.Equal(t, 0, standings[0].Rows[0].Played, "Matches against unknown teams should be ignored")
+
+	main := standings[1]
+	require.Equal(t, []string{"Alpha", "Bravo", "Charlie"}, []string{main.Rows[0].Team.Name, main.Rows[1].Team.Name, main.Rows[2].Team.Name},
+		"A three way tie should fall to map difference after head-to-head is level")
+	alpha, charlie := main.Rows[0], main.Rows[2]
+	require.Equal(t, Standing{Team: teams[0], Played: 2, Wins: 1, Losses: 1, MapWins: 1, MapLosses: 0, Points: 3, ForfeitLosses: 1}, alpha)
+	require.Equal(t, 1, charlie.ForfeitWins)
+}
+
+func TestStandingsWithoutIsHome(t *testing.T) {
+	var m Match
+	require.NoError(t, json.Unmarshal([]byte(match5256), &m))
+	teams := []Team{{Id: 5979, Name: "nut.city"}, {Id: 5819, Name: "Sunny"}}
+	rows := ComputeStandings(teams, []Match{m})[0].Rows
+	require.Equal(t, Standing{Team: teams[0], Played: 1, Wins: 1, MapWins: 1, Points: 2.75}, rows[0],
+		"The winner played away in 5256, so the away score is theirs")
+	require.Equal(t, Standing{Team: teams[1], Played: 1, Losses: 1, MapLosses: 1, Points: 0.25}, rows[1])
+
+	m.WinnerId = 0
+	rows = ComputeStandings(teams, []Match{m})[0].Rows
+	require.Equal(t, 1, rows[0].Wins, "Points should still decide the winner")
+	require.Equal(t, 0, rows[0].MapWins+rows[0].MapLosses, "Maps shouldn't be counted when the sides can't be told")
+}
+
+func TestStandingsHeadToHead(t *testing.T) {
+	teams := []Team{{Id: 1, Name: "Alpha"}, {Id: 2, Name: "Bravo"}, {Id: 3, Name: "Charlie"}, {Id: 4, Name: "Delta"}}
+	matches := []Match{
+		standingsMatch(10, 1, "3", 4, "0", MatchMap{HomeScore: 5, AwayScore: 0}),
+		standingsMatch(11, 2, "3", 1, "0", MatchMap{HomeScore: 5, AwayScore: 4}),
+		standingsMatch(12, 3, "3", 2, "0", MatchMap{HomeScore: 5, AwayScore: 0}),
+		standingsMatch(13, 3, "3", 4, "0", MatchMap{HomeScore: 5, AwayScore: 0}),
+	}
+	rows := ComputeStandings(teams, matches)[0].Rows
+	require.Equal(t, []string{"Charlie", "Bravo", "Alpha", "Delta"}, []string{rows[0].Team.Name, rows[1].Team.Name, rows[2].Team.Name, rows[3].Team.Name},
+		"Bravo beat Alpha, so should be above them even though everything else is level")
+	require.Equal(t, rows[1].Points, rows[2].Points)
+	require.Equal(t, rows[1].MapDiff(), rows[2].MapDiff())
+}
+
+func TestStandings(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/seasons/67", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"name": "Sixes S2", "participatingTeams": [5979, 5819], "matchesPlayedDuringSeason": [5256, 404]}`)
+	})
+	mux.HandleFunc("/v0/teams/5979", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"teamId": 5979, "name": "nut.city", "divisionId": 12, "divisionName": "Intermediate"}`)
+	})
+	mux.HandleFunc("/v0/teams/5819", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"teamId": 5819, "name": "Sunny", "divisionId": 12, "divisionName": "Intermediate"}`)
+	})
+	mux.HandleFunc("/v0/matches/5256", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"matchId": 5256, "winner": 5979, "teams": [
+			{"teamId": 5979, "isHome": false, "points": "2.75"}, {"teamId": 5819, "isHome": false, "points": "0.25"}
+		], "maps": [{"mapName": "cp_snakewater_final1", "homeScore": 1, "awayScore": 5}]}`)
+	})
+	mux.HandleFunc("/v0/matches/404", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	tr := newTestRGL(t, mux)
+
+	standings, err := tr.Standings(context.Background(), 67)
+	require.NoError(t, err)
+	require.Len(t, standings, 1)
+	require.Equal(t, "Intermediate", standings[0].DivName)
+	require.Equal(t, "nut.city", standings[0].Rows[0].Team.Name)
+	require.Equal(t, 2.75, standings[0].Rows[0].Points)
+	require.Equal(t, 1, standings[0].Rows[1].Losses)
+	require.Equal(t, 1, standings[0].Rows[0].MapWins, "Map wins should go to the winner even with isHome false for both")
+	require.Equal(t, 1, standings[0].Rows[1].MapLosses)
+}
@@ -0,0 +1,151 @@
+package rgl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Steam64 ID, like "76561198098770013". Use ParseSteamID to get one from any other format.
+// Methods taking a SteamID also accept the other formats and normalize them, so r.GetPlayer("[U:1:138504285]") works.
+type SteamID string
+
+// Returned (wrapped) for strings that aren't any known Steam ID format
+var ErrInvalidSteamID = errors.New("Invalid steam id")
+
+// Returned (wrapped) for steamcommunity.com/id/<vanity> links, which need a SteamResolver to look up
+var ErrVanityURL = errors.New("Vanity URL needs to be resolved")
+
+// Steam64 of the individual account with account ID 0
+const steam64Base = 76561197960265728
+
+// Parse a Steam ID in any of these formats:
+//
+//	76561198098770013                                      Steam64
+//	STEAM_0:1:69252142                                     SteamID2 (STEAM_1:... too)
+//	[U:1:138504285]                                        SteamID3 (brackets optional)
+//	https://steamcommunity.com/profiles/76561198098770013  Profile URL
+//
+// Vanity URLs give an error wrapping ErrVanityURL.
+func ParseSteamID(s string) (SteamID, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(strings.ToUpper(s), "STEAM_"):
+		return parseSteamID2(s)
+	case strings.HasPrefix(s, "[U:") || strings.HasPrefix(s, "U:"):
+		return parseSteamID3(s)
+	case strings.Contains(s, "steamcommunity.com/"):
+		return parseProfileURL(s)
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || len(s) != 17 || n <= steam64Base || n-steam64Base > 0xFFFFFFFF {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSteamID, s)
+	}
+	return SteamID(s), nil
+}
+
+func fromAccountID(account uint64) SteamID {
+	return SteamID(strconv.FormatUint(steam64Base+account, 10))
+}
+
+func parseSteamID2(s string) (SteamID, error) {
+	parts := strings.Split(s[len("STEAM_"):], ":")
+	if len(parts) != 3 || (parts[0] != "0" && parts[0] != "1") || (parts[1] != "0" && parts[1] != "1") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSteamID, s)
+	}
+	z, err := strconv.ParseUint(parts[2], 10, 31)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSteamID, s)
+	}
+	y := uint64(0)
+	if parts[1] == "1" {
+		y = 1
+	}
+	return fromAccountID(z*2 + y), nil
+}
+
+func parseSteamID3(s string) (SteamID, error) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	parts := strings.Split(inner, ":")
+	if len(parts) != 3 || parts[0] != "U" || parts[1] != "1" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSteamID, s)
+	}
+	account, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil || account == 0 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSteamID, s)
+	}
+	return fromAccountID(account), nil
+}
+
+func parseProfileURL(s string) (SteamID, error) {
+	_, path, _ := strings.Cut(s, "steamcommunity.com/")
+	kind, rest, _ := strings.Cut(path, "/")
+	value, _, _ := strings.Cut(rest, "/")
+	value, _, _ = strings.Cut(value, "?")
+	switch kind {
+	case "profiles":
+		id, err := ParseSteamID(value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %q", ErrInvalidSteamID, s)
+		}
+		return id, nil
+	case "id":
+		if value != "" {
+			return "", fmt.Errorf("%w: %q", ErrVanityURL, value)
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidSteamID, s)
+}
+
+// Normalize id to a Steam64, whatever format it's in
+func (id SteamID) Steam64() (SteamID, error) {
+	return ParseSteamID(string(id))
+}
+
+// Whether id is a valid Steam64 (not just any parseable format)
+func (id SteamID) Valid() bool {
+	parsed, err := ParseSteamID(string(id))
+	return err == nil && parsed == id
+}
+
+// The 32 bit account ID, which the other formats are built from. 0 if id isn't valid.
+func (id SteamID) AccountID() uint32 {
+	parsed, err := id.Steam64()
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.ParseUint(string(parsed), 10, 64)
+	return uint32(n - steam64Base)
+}
+
+// id as a SteamID2, like "STEAM_0:1:69252142" (the format TF2 servers use in banned_user.cfg). "" if id isn't valid.
+func (id SteamID) SteamID2() string {
+	account := id.AccountID()
+	if account == 0 {
+		return ""
+	}
+	return fmt.Sprintf("STEAM_0:%d:%d", account%2, account/2)
+}
+
+// id as a SteamID3, like "[U:1:138504285]" (the format in server logs). "" if id isn't valid.
+func (id SteamID) SteamID3() string {
+	account := id.AccountID()
+	if account == 0 {
+		return ""
+	}
+	return fmt.Sprintf("[U:1:%d]", account)
+}
+
+// Link to the steam community profile. "" if id isn't valid.
+func (id SteamID) ProfileURL() string {
+	parsed, err := id.Steam64()
+	if err != nil {
+		return ""
+	}
+	return "https://steamcommunity.com/profiles/" + string(parsed)
+}
+
+func (id SteamID) String() string {
+	return string(id)
+}
@@ -0,0 +1,48 @@
+package rgl
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseSteamID(t *testing.T) {
+	const zidgel = SteamID("76561198098770013")
+	for _, s := range []string{
+		"76561198098770013",
+		" 76561198098770013\n",
+		"STEAM_0:1:69252142",
+		"STEAM_1:1:69252142",
+		"[U:1:138504285]",
+		"U:1:138504285",
+		"https://steamcommunity.com/profiles/76561198098770013",
+		"steamcommunity.com/profiles/76561198098770013/",
+		"https://steamcommunity.com/profiles/[U:1:138504285]",
+	} {
+		id, err := ParseSteamID(s)
+		require.NoError(t, err, s)
+		require.Equal(t, zidgel, id, s)
+	}
+
+	for _, s := range []string{"", "unvalidated12345", "7656111111111111111111111111", "765611980987700133", "STEAM_0:2:1", "[G:1:123]", "https://steamcommunity.com/groups/rgl"} {
+		_, err := ParseSteamID(s)
+		require.ErrorIs(t, err, ErrInvalidSteamID, s)
+	}
+
+	_, err := ParseSteamID("https://steamcommunity.com/id/captainzidgel/")
+	require.ErrorIs(t, err, ErrVanityURL)
+}
+
+func TestSteamIDFormats(t *testing.T) {
+	id := SteamID("76561198098770013")
+	require.True(t, id.Valid())
+	require.Equal(t, uint32(138504285), id.AccountID())
+	require.Equal(t, "STEAM_0:1:69252142", id.SteamID2())
+	require.Equal(t, "[U:1:138504285]", id.SteamID3())
+	require.Equal(t, "https://steamcommunity.com/profiles/76561198098770013", id.ProfileURL())
+
+	id = SteamID("[U:1:138504285]")
+	require.False(t, id.Valid(), "Only Steam64s are Valid")
+	require.Equal(t, "STEAM_0:1:69252142", id.SteamID2(), "Conversions should work from any format")
+
+	require.Equal(t, "", SteamID("garbage").SteamID2())
+}
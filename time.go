@@ -0,0 +1,61 @@
+package rgl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A timestamp from RGL. Decodes null and "" to the zero time (check with IsZero), and fails decoding
+// on anything it can't parse instead of silently giving a zero time like ToGoTime.
+type Time struct {
+	time.Time
+}
+
+// Layouts RGL has been seen to use, most common first
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.999Z",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999",
+	"2006-01-02 15:04:05.999",
+	"2006-01-02",
+}
+
+// Parse an RGL timestamp in any known layout. "" parses to the zero Time.
+func ParseTime(s string) (Time, error) {
+	if s == "" {
+		return Time{}, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Time{t}, nil
+		}
+	}
+	return Time{}, fmt.Errorf("Unrecognized time format %q", s)
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Time{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Time should be a string: %w", err)
+	}
+	parsed, err := ParseTime(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// Encodes as RGL does (UTC, millisecond precision), or null for the zero time
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format("2006-01-02T15:04:05.000Z"))
+}
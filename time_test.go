@@ -0,0 +1,40 @@
+package rgl
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	var h PlayerTeamHistory
+	err := json.Unmarshal([]byte(`{"startedAt": "2020-01-07T11:52:14.640Z", "leftAt": null}`), &h)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 1, 7, 11, 52, 14, 640000000, time.UTC), h.Started.Time)
+	require.True(t, h.Left.IsZero(), "null should decode to the zero time")
+
+	var p Player
+	require.NoError(t, json.Unmarshal([]byte(`{"updatedAt": ""}`), &p))
+	require.True(t, p.Updated.IsZero(), "Empty strings should decode to the zero time")
+
+	for _, s := range []string{"2020-01-07T11:52:14Z", "2020-01-07T11:52:14.640+00:00", "2020-01-07 11:52:14", "2020-01-07"} {
+		parsed, err := ParseTime(s)
+		require.NoError(t, err, s)
+		require.Equal(t, 2020, parsed.Year(), s)
+	}
+
+	err = json.Unmarshal([]byte(`{"updatedAt": "last tuesday"}`), &p)
+	require.Error(t, err, "Invalid timestamps should fail decoding")
+
+	m := Match{MatchDate: Time{time.Date(2020, 1, 15, 3, 30, 0, 0, time.UTC)}}
+	raw, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"matchDate":"2020-01-15T03:30:00.000Z"`)
+	var back Match
+	require.NoError(t, json.Unmarshal(raw, &back))
+	require.True(t, m.MatchDate.Equal(back.MatchDate.Time), "Should round trip")
+
+	raw, _ = json.Marshal(Ban{})
+	require.Contains(t, string(raw), `"endsAt":null`)
+}